@@ -0,0 +1,32 @@
+//Package api exposes the handler of the box-price lambda to run it outside of API Gateway, see cmd/localapi
+package api
+
+import (
+	"context"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/box-price/v1/internal/ctx"
+	"github.com/chandy20/prueba-smartjobandina/beer/box-price/v1/internal/di"
+	"github.com/chandy20/prueba-smartjobandina/beer/exchange"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/sirupsen/logrus"
+)
+
+//Repository beers the handler reads, every repository.Beers satisfies it
+type Repository interface {
+	Find(context.Context, int) (model.Beer, error)
+}
+
+//New builds the handler of the lambda with the same dependencies its main uses
+func New() (lib.HandlerFunc, error) {
+	handler, err := di.Initialize()
+	if err != nil {
+		return nil, err
+	}
+	return handler.Handler, nil
+}
+
+//NewHandler builds the handler over repository converting prices with converter
+func NewHandler(repository Repository, converter exchange.CurrencyConverter, logger *logrus.Logger) lib.HandlerFunc {
+	return ctx.NewHandler(repository, converter, logger).Handler
+}
@@ -3,135 +3,169 @@ package ctx
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
-	"io/ioutil"
+	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/exchange"
 	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
 	"github.com/sirupsen/logrus"
 )
 
 //beerRepositoryInterface contract for beer repository
 type beerRepositoryInterface interface {
-	Find(int) (model.Beer, error)
+	Find(context.Context, int) (model.Beer, error)
 }
 
-//htpClientInterface contract for http client
-type htpClientInterface interface {
-	Do(req *http.Request) (*http.Response, error)
-}
+//defaultQuantity number of beers in a box when quantity is not sent
+const defaultQuantity = 6
+
+//maxQuantity biggest box that can be priced
+const maxQuantity = 1000
+
+//roundingMode rounding used to convert unit price to the minor units of requested currency
+const roundingMode = money.HalfEven
 
+//codeUpstreamConversion code of the error returned when the currency api fails to convert
+const codeUpstreamConversion = "currency_conversion_failed"
+
+//responseLambda body returned by lambda
 type responseLambda struct {
-	PriceTotal float64 `json:"price_total"`
+	PriceTotal money.Decimal `json:"price_total"`
+	Currency   string        `json:"currency"`
+	UnitPrice  money.Decimal `json:"unit_price"`
+	Quantity   int           `json:"quantity"`
+	Rate       float64       `json:"rate"`
+	RateStale  bool          `json:"rate_stale"`
 }
 
 //Handler main struct for lambda
 type Handler struct {
 	beersRepository   beerRepositoryInterface
-	httpClient        htpClientInterface
-	accessKeyCurrency string //5d50da19b1c34e648b2aca13e2ac05fc
+	currencyConverter exchange.CurrencyConverter
 	logger            *logrus.Logger
 }
 
 //Handler main function for lambda
 func (h *Handler) Handler(
-	_ context.Context,
+	ctx context.Context,
 	req events.APIGatewayProxyRequest,
 ) (events.APIGatewayProxyResponse, error) {
+	logger := lib.Logger(ctx, h.logger)
+
 	IDString := req.PathParameters["beerID"]
 	if strings.TrimSpace(IDString) == "" {
-		return lib.ResponseError(http.StatusBadRequest, errors.New("beerID_can_not_be_empty")), nil
+		return lib.ResponseError(req.Path, errors.Validation("beerID_can_not_be_empty")), nil
 	}
 
 	currency := req.QueryStringParameters["currency"]
 	if strings.TrimSpace(currency) == "" {
-		return lib.ResponseError(http.StatusBadRequest, errors.New("currency_can_not_be_empty")), nil
-
-	}
-
-	quantityString := req.QueryStringParameters["quantity"]
-	if strings.TrimSpace(quantityString) == "" {
-		return lib.ResponseError(http.StatusBadRequest, errors.New("quantity_can_not_be_empty")), nil
+		return lib.ResponseError(req.Path, errors.Validation("currency_can_not_be_empty")), nil
 
 	}
 
 	ID, err := strconv.Atoi(IDString)
 	if err != nil {
-		return lib.ResponseError(http.StatusBadRequest, errors.New("beerID_is_not_a_number")), nil
+		return lib.ResponseError(req.Path, errors.Validation("beerID_is_not_a_number")), nil
 
 	}
-	_, err = strconv.Atoi(quantityString)
+
+	currency, err = money.NormalizeCurrency(currency)
 	if err != nil {
-		return lib.ResponseError(http.StatusBadRequest, errors.New("quantity_is_not_a_number")), nil
+		return lib.ResponseError(req.Path, err), nil
+	}
 
+	quantity := defaultQuantity
+	quantityString := req.QueryStringParameters["quantity"]
+	if strings.TrimSpace(quantityString) != "" {
+		quantity, err = strconv.Atoi(quantityString)
+		if err != nil {
+			return lib.ResponseError(req.Path, errors.Validation("quantity_is_not_a_number")), nil
+		}
+		if quantity < 1 {
+			return lib.ResponseError(req.Path, errors.Validation("quantity_must_be_greater_than_zero")), nil
+		}
+		if quantity > maxQuantity {
+			return lib.ResponseError(req.Path, errors.Validation("quantity_must_be_at_most_1000")), nil
+		}
 	}
 
-	beer, err := h.beersRepository.Find(ID)
+	beer, err := h.beersRepository.Find(ctx, ID)
 	if err != nil {
-		return lib.ResponseError(http.StatusInternalServerError, err), nil
+		return lib.ResponseError(req.Path, err), nil
 
 	}
 
 	if beer.ID == 0 {
-		return lib.ResponseError(http.StatusNotFound, errors.New("beerID_does_not_exist")), nil
+		return lib.ResponseError(req.Path, errors.NotFound("beerID_does_not_exist")), nil
 	}
-	uri := fmt.Sprintf(
-		"https://api.currencylayer.com/convert?access_key=%v&from=%v&to=%v&amount=%v",
-		h.accessKeyCurrency,
-		beer.Currency,
-		currency,
-		beer.Price,
-	)
-
-	request, err := http.NewRequest(http.MethodGet, uri, nil)
+
+	if !beer.Active() {
+		return lib.ResponseError(req.Path, errors.Gone("beerID_was_deleted")), nil
+	}
+
+	quote, err := h.currencyConverter.Rate(ctx, beer.Currency, currency)
+	if err != nil {
+		logger.WithError(err).Error("currency api could not convert price")
+		if errors.Is(err, exchange.ErrRateNotFound) {
+			return lib.ResponseError(req.Path, err), nil
+		}
+		return lib.ResponseError(req.Path, errors.FromContext(ctx, errors.Upstream(codeUpstreamConversion, err))), nil
+	}
+
+	price, err := beer.Money()
 	if err != nil {
-		body, _ := json.Marshal(map[string]interface{}{
-			"message": err.Error(),
-		})
-		return lib.JSONResponse(http.StatusInternalServerError, body), nil
+		logger.WithError(err).WithField("beer", beer).Error("beer price does not fit its currency")
+		return lib.ResponseError(req.Path, err), nil
 	}
 
-	response, err := h.httpClient.Do(request)
-	defer response.Body.Close()
+	rate, err := money.RateFromFloat(quote.Rate)
 	if err != nil {
-		body, _ := json.Marshal(map[string]interface{}{
-			"message": err.Error(),
-		})
-		return lib.JSONResponse(http.StatusBadRequest, body), nil
+		logger.WithError(err).Error("currency api returned an invalid rate")
+		return lib.ResponseError(req.Path, errors.Upstream(codeUpstreamConversion, err)), nil
 	}
-	responseBody, err := ioutil.ReadAll(response.Body)
+
+	unitPrice, err := money.FromRat(new(big.Rat).Mul(price.Rat(), rate), currency, roundingMode)
+	if err != nil {
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	priceTotal, err := unitPrice.Mul(int64(quantity))
 	if err != nil {
-		body, _ := json.Marshal(map[string]interface{}{
-			"message": err.Error(),
-		})
-		return lib.JSONResponse(http.StatusBadRequest, body), nil
+		logger.WithError(err).WithField("quantity", quantity).Error("price of the box does not fit its currency")
+		return lib.ResponseError(req.Path, err), nil
 	}
-	h.logger.WithField("response_body", responseBody).Info("response from api")
 
-	//Queda desarrollo inconcludo por que libreria suministrada para la prueba es de pago
-	//faltó poner api key en variables de entorno e inyectarla al repositorio
-	//Hacer operacion matematica con la respuesta y la cantidad de cervezas por default (6) y retornar respuesta exitosa
+	body, err := json.Marshal(responseLambda{
+		PriceTotal: priceTotal.Decimal(),
+		Currency:   currency,
+		UnitPrice:  unitPrice.Decimal(),
+		Quantity:   quantity,
+		Rate:       quote.Rate,
+		RateStale:  quote.Stale,
+	})
+	if err != nil {
+		return lib.ResponseError(req.Path, err), nil
+	}
 
-	return lib.EmptyResponse(http.StatusOK), nil
+	return lib.JSONResponse(http.StatusOK, body), nil
 
 }
 
 //NewHandler construct for Handler
 func NewHandler(
 	beersRepository beerRepositoryInterface,
-	httpClient htpClientInterface,
-	accessKeyCurrency string,
+	currencyConverter exchange.CurrencyConverter,
 	logger *logrus.Logger,
 ) *Handler {
 	return &Handler{
 		beersRepository:   beersRepository,
-		httpClient:        httpClient,
-		accessKeyCurrency: accessKeyCurrency,
+		currencyConverter: currencyConverter,
 		logger:            logger,
 	}
 }
@@ -3,8 +3,11 @@ package ctx
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/exchange"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 	"net/http"
@@ -18,20 +21,61 @@ type beersRepositoryMock struct {
 	mock.Mock
 }
 
-func (b *beersRepositoryMock) Find(ID int) (model.Beer, error) {
+func (b *beersRepositoryMock) Find(_ context.Context, ID int) (model.Beer, error) {
 	args := b.Called(ID)
 	return args.Get(0).(model.Beer), args.Error(1)
 }
 
+//currencyConverterMock mock for represent currency converter
+type currencyConverterMock struct {
+	mock.Mock
+}
+
+func (c *currencyConverterMock) Rate(_ context.Context, from, to string) (exchange.Quote, error) {
+	args := c.Called(from, to)
+	return args.Get(0).(exchange.Quote), args.Error(1)
+}
+
+//expiredContext context whose deadline already passed
+func expiredContext() context.Context {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	cancel()
+	return ctx
+}
+
+//go:embed golden_files/successResponse.json
+var successResponse []byte
+
+//go:embed golden_files/defaultQuantityResponse.json
+var defaultQuantityResponse []byte
+
+//go:embed golden_files/staleRateResponse.json
+var staleRateResponse []byte
+
 func TestHandler_Handler(t *testing.T) {
 	headers := map[string]string{
 		"Content-Type":                     "application/json",
 		"Access-Control-Allow-Origin":      "*",
 		"Access-Control-Allow-Credentials": "true",
 	}
+	problemHeaders := map[string]string{
+		"Content-Type":                     "application/problem+json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+
+	pilsen := model.Beer{
+		ID:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    money.NewDecimal(2400, 0),
+		Currency: "COP",
+	}
 
 	type mocks struct {
-		beersRepository *beersRepositoryMock
+		beersRepository   *beersRepositoryMock
+		currencyConverter *currencyConverterMock
 	}
 
 	type fields struct {
@@ -58,7 +102,8 @@ func TestHandler_Handler(t *testing.T) {
 				logger: logrus.New(),
 			},
 			mocks: mocks{
-				beersRepository: &beersRepositoryMock{},
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
 			},
 			mocker: func(m mocks) {},
 			args: args{
@@ -69,8 +114,8 @@ func TestHandler_Handler(t *testing.T) {
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusBadRequest,
-				Headers:         headers,
-				Body:            `{"message":"beerID_can_not_be_empty"}`,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/beerID_can_not_be_empty","title":"Bad Request","status":400,"detail":"beerID_can_not_be_empty"}`,
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
@@ -81,7 +126,8 @@ func TestHandler_Handler(t *testing.T) {
 				logger: logrus.New(),
 			},
 			mocks: mocks{
-				beersRepository: &beersRepositoryMock{},
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
 			},
 			mocker: func(m mocks) {},
 			args: args{
@@ -98,8 +144,8 @@ func TestHandler_Handler(t *testing.T) {
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusBadRequest,
-				Headers:         headers,
-				Body:            `{"message":"beerID_is_not_a_number"}`,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/beerID_is_not_a_number","title":"Bad Request","status":400,"detail":"beerID_is_not_a_number"}`,
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
@@ -110,7 +156,8 @@ func TestHandler_Handler(t *testing.T) {
 				logger: logrus.New(),
 			},
 			mocks: mocks{
-				beersRepository: &beersRepositoryMock{},
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
 			},
 			mocker: func(m mocks) {
 			},
@@ -127,19 +174,20 @@ func TestHandler_Handler(t *testing.T) {
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusBadRequest,
-				Headers:         headers,
-				Body:            `{"message":"currency_can_not_be_empty"}`,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/currency_can_not_be_empty","title":"Bad Request","status":400,"detail":"currency_can_not_be_empty"}`,
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
 		},
 		{
-			name: "should_return_error_because_quantity_is_empty",
+			name: "should_return_error_because_quantity_is_not_a_number",
 			fields: fields{
 				logger: logrus.New(),
 			},
 			mocks: mocks{
-				beersRepository: &beersRepositoryMock{},
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
 			},
 			mocker: func(m mocks) {
 			},
@@ -150,14 +198,183 @@ func TestHandler_Handler(t *testing.T) {
 						"beerID": "1",
 					},
 					QueryStringParameters: map[string]string{
-						"currency": "2",
+						"currency": "USD",
+						"quantity": "a",
 					},
 				},
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusBadRequest,
-				Headers:         headers,
-				Body:            `{"message":"quantity_can_not_be_empty"}`,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/quantity_is_not_a_number","title":"Bad Request","status":400,"detail":"quantity_is_not_a_number"}`,
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_quantity_is_not_positive",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "USD",
+						"quantity": "0",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusBadRequest,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/quantity_must_be_greater_than_zero","title":"Bad Request","status":400,"detail":"quantity_must_be_greater_than_zero"}`,
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_beer_was_deleted",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+				deletedAt := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+				deleted := pilsen
+				deleted.DeletedAt = &deletedAt
+				m.beersRepository.On("Find", 1).Return(deleted, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "USD",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusGone,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/beerID_was_deleted","title":"Gone","status":410,"detail":"beerID_was_deleted"}`,
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_quantity_is_too_big",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "USD",
+						"quantity": "9223372036854775",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusBadRequest,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/quantity_must_be_at_most_1000","title":"Bad Request","status":400,"detail":"quantity_must_be_at_most_1000"}`,
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_price_total_overflows",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(model.Beer{
+					ID:       1,
+					Name:     "Pilsen",
+					Brewery:  "Bavaria",
+					Country:  "Japan",
+					Price:    money.NewDecimal(92233720368547758, 0),
+					Currency: "JPY",
+				}, nil).Once()
+				m.currencyConverter.On("Rate", "JPY", "JPY").Return(
+					exchange.Quote{From: "JPY", To: "JPY", Rate: 1}, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "JPY",
+						"quantity": "1000",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusBadRequest,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/amount_overflow","title":"Bad Request","status":400,"detail":"amount_overflow"}`,
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_currency_is_not_supported",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "ABC",
+						"quantity": "2",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusBadRequest,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/currency_not_supported","title":"Bad Request","status":400,"detail":"currency_not_supported"}`,
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
@@ -168,7 +385,8 @@ func TestHandler_Handler(t *testing.T) {
 				logger: logrus.New(),
 			},
 			mocks: mocks{
-				beersRepository: &beersRepositoryMock{},
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
 			},
 			mocker: func(m mocks) {
 				m.beersRepository.On("Find", 1).Return(model.Beer{}, nil).Once()
@@ -187,8 +405,105 @@ func TestHandler_Handler(t *testing.T) {
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusNotFound,
-				Headers:         headers,
-				Body:            `{"message":"beerID_does_not_exist"}`,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/beerID_does_not_exist","title":"Not Found","status":404,"detail":"beerID_does_not_exist"}`,
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_bad_gateway_because_currency_api_fails",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(pilsen, nil).Once()
+				m.currencyConverter.On("Rate", "COP", "USD").Return(exchange.Quote{}, errors.New("error")).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "USD",
+						"quantity": "2",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusBadGateway,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/currency_conversion_failed","title":"Bad Gateway","status":502,"detail":"currency_conversion_failed"}`,
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_gateway_timeout_because_currency_api_ran_out_of_time",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(pilsen, nil).Once()
+				m.currencyConverter.On("Rate", "COP", "USD").Return(exchange.Quote{}, context.DeadlineExceeded).Once()
+			},
+			args: args{
+				ctx: expiredContext(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "USD",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusGatewayTimeout,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/request_timed_out","title":"Gateway Timeout","status":504,"detail":"request_timed_out"}`,
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_currency_api_does_not_know_the_rate",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(pilsen, nil).Once()
+				m.currencyConverter.On("Rate", "COP", "USD").Return(exchange.Quote{}, exchange.ErrRateNotFound).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "USD",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusBadRequest,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/exchange_rate_not_found","title":"Bad Request","status":400,"detail":"exchange_rate_not_found"}`,
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
@@ -199,18 +514,13 @@ func TestHandler_Handler(t *testing.T) {
 				logger: logrus.New(),
 			},
 			mocks: mocks{
-				beersRepository: &beersRepositoryMock{},
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
 			},
 			mocker: func(m mocks) {
-				m.beersRepository.On("Find", 1).Return(
-					model.Beer{
-						ID:       1,
-						Name:     "Pilsen",
-						Brewery:  "Bavaria",
-						Country:  "Colombia",
-						Price:    2400,
-						Currency: "COP",
-					}, nil).Once()
+				m.beersRepository.On("Find", 1).Return(pilsen, nil).Once()
+				m.currencyConverter.On("Rate", "COP", "USD").Return(
+					exchange.Quote{From: "COP", To: "USD", Rate: 0.0003125}, nil).Once()
 			},
 			args: args{
 				ctx: context.Background(),
@@ -227,7 +537,74 @@ func TestHandler_Handler(t *testing.T) {
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusOK,
 				Headers:         headers,
-				Body:            "",
+				Body:            string(successResponse),
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_a_success_response_with_stale_rate",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(pilsen, nil).Once()
+				m.currencyConverter.On("Rate", "COP", "USD").Return(
+					exchange.Quote{From: "COP", To: "USD", Rate: 0.0003125, Stale: true}, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "USD",
+						"quantity": "2",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusOK,
+				Headers:         headers,
+				Body:            string(staleRateResponse),
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_a_success_response_for_a_default_box",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository:   &beersRepositoryMock{},
+				currencyConverter: &currencyConverterMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(pilsen, nil).Once()
+				m.currencyConverter.On("Rate", "COP", "USD").Return(
+					exchange.Quote{From: "COP", To: "USD", Rate: 0.0003125}, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"currency": "usd",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusOK,
+				Headers:         headers,
+				Body:            string(defaultQuantityResponse),
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
@@ -236,10 +613,7 @@ func TestHandler_Handler(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mocker(tt.mocks)
-			client := http.Client{
-				Timeout: time.Second * time.Duration(60),
-			}
-			h := NewHandler(tt.mocks.beersRepository, &client, "", tt.fields.logger)
+			h := NewHandler(tt.mocks.beersRepository, tt.mocks.currencyConverter, tt.fields.logger)
 			got, err := h.Handler(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Handler() error = %v, wantErr %v", err, tt.wantErr)
@@ -248,6 +622,9 @@ func TestHandler_Handler(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Handler() got = %v, want %v", got, tt.want)
 			}
+
+			tt.mocks.beersRepository.AssertExpectations(t)
+			tt.mocks.currencyConverter.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,83 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/chandy20/prueba-smartjobandina/beer/box-price/v1/internal/ctx"
+	"github.com/chandy20/prueba-smartjobandina/beer/exchange"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"os"
+	"time"
+)
+
+//httpClientTimeout max time to wait for the currency api
+const httpClientTimeout = 10 * time.Second
+
+//defaultRatesTTL time a quote is reused before asking the currency api again
+const defaultRatesTTL = time.Hour
+
+func providerAWSConfig() []*aws.Config {
+	if endpoint := os.Getenv("DYNAMODB_ENDPOINT"); endpoint != "" {
+		return []*aws.Config{aws.NewConfig().WithEndpoint(endpoint)}
+	}
+	return nil
+}
+
+func providerBeerRepository(
+	client *dynamodb.DynamoDB,
+	logger *logrus.Logger,
+) (*repository.BeerRepository, error) {
+	tableBeers := os.Getenv("DYNAMODB_BEERS")
+	if tableBeers == "" {
+		return nil, errors.New("variable DYNAMODB_BEERS is not defined")
+	}
+	return repository.NewBeerRepository(client, tableBeers, logger), nil
+}
+
+func providerHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: httpClientTimeout,
+	}
+}
+
+func providerCurrencyConverter(
+	httpClient *http.Client,
+	client *dynamodb.DynamoDB,
+) (exchange.CurrencyConverter, error) {
+	converter, err := exchange.New(exchange.Config{
+		Provider:   os.Getenv("CURRENCY_PROVIDER"),
+		AccessKey:  os.Getenv("ACCESS_KEY_CURRENCY"),
+		RatesFile:  os.Getenv("CURRENCY_RATES_FILE"),
+		HTTPClient: httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := defaultRatesTTL
+	if value := os.Getenv("CURRENCY_RATES_TTL"); value != "" {
+		ttl, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("variable CURRENCY_RATES_TTL is not a duration: %w", err)
+		}
+	}
+
+	var store exchange.RateStore
+	if tableRates := os.Getenv("DYNAMODB_RATES"); tableRates != "" {
+		store = exchange.NewDynamoRateStore(client, tableRates)
+	}
+
+	return exchange.NewCache(converter, store, ttl), nil
+}
+
+func provideNewHandler(
+	beerRepository *repository.BeerRepository,
+	currencyConverter exchange.CurrencyConverter,
+	logger *logrus.Logger,
+) *ctx.Handler {
+	return ctx.NewHandler(beerRepository, currencyConverter, logger)
+}
@@ -0,0 +1,313 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/routes"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+)
+
+const (
+	//defaultRetries times a GET is sent again when the API or the network fails
+	defaultRetries = 2
+	//defaultBackoff wait before the first retry, it doubles on every retry
+	defaultBackoff = 100 * time.Millisecond
+	//defaultTimeout max time to wait for one response, the gateway gives up after 29 seconds
+	defaultTimeout = 30 * time.Second
+)
+
+//httpClientInterface contract for http client
+type httpClientInterface interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+//Config values to build a Client, zero values take the defaults
+type Config struct {
+	BaseURL    string
+	HTTPClient httpClientInterface
+	//Retries times a GET is sent again after a network error or a 502, 503 or 504, -1 disables them
+	Retries int
+	Backoff time.Duration
+}
+
+//Client typed client of the beer API, errors answered by the API are *APIError
+type Client struct {
+	baseURL    string
+	httpClient httpClientInterface
+	retries    int
+	backoff    time.Duration
+}
+
+//BoxPrice price of a box of beers in a currency
+type BoxPrice struct {
+	PriceTotal money.Decimal `json:"price_total"`
+	Currency   string        `json:"currency"`
+	UnitPrice  money.Decimal `json:"unit_price"`
+	Quantity   int           `json:"quantity"`
+	Rate       float64       `json:"rate"`
+	RateStale  bool          `json:"rate_stale"`
+}
+
+//ListQuery filters, order and page size of ListBeers, empty fields are not sent
+type ListQuery struct {
+	Country  string
+	Brewery  string
+	Name     string
+	Currency string
+	MinPrice *money.Decimal
+	MaxPrice *money.Decimal
+	//Sort one of price, -price, name or -name, sorting by price and the price bounds require Currency
+	Sort  string
+	Limit int
+}
+
+//values query string of q
+func (q ListQuery) values() url.Values {
+	values := url.Values{}
+	set := func(name, value string) {
+		if value != "" {
+			values.Set(name, value)
+		}
+	}
+	set("country", q.Country)
+	set("brewery", q.Brewery)
+	set("name", q.Name)
+	set("currency", q.Currency)
+	set("sort", q.Sort)
+	if q.MinPrice != nil {
+		values.Set("min_price", q.MinPrice.String())
+	}
+	if q.MaxPrice != nil {
+		values.Set("max_price", q.MaxPrice.String())
+	}
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return values
+}
+
+//Page one page of beers, NextCursor is empty on the last page
+type Page struct {
+	Items      []model.Beer `json:"items"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+//CreateBeer creates beer, the API gives the ID when it is 0. It is never retried
+func (c *Client) CreateBeer(ctx context.Context, beer model.Beer) (model.Beer, error) {
+	body, err := json.Marshal(beer)
+	if err != nil {
+		return model.Beer{}, err
+	}
+	var created model.Beer
+	err = c.do(ctx, http.MethodPost, routes.Beers, nil, body, &created)
+	return created, err
+}
+
+//GetBeer finds the beer with ID, a deleted beer is an error of kind gone
+func (c *Client) GetBeer(ctx context.Context, ID int) (model.Beer, error) {
+	var beer model.Beer
+	err := c.do(ctx, http.MethodGet, routes.BeerPath(routes.Beer, ID), nil, nil, &beer)
+	return beer, err
+}
+
+//ListPage reads the page of beers after cursor, an empty cursor reads the first one
+func (c *Client) ListPage(ctx context.Context, query ListQuery, cursor string) (Page, error) {
+	values := query.values()
+	if cursor != "" {
+		values.Set("cursor", cursor)
+	}
+	var page Page
+	err := c.do(ctx, http.MethodGet, routes.Beers, values, nil, &page)
+	return page, err
+}
+
+//ListBeers iterator over every beer matching query, pages are read as the iteration goes
+func (c *Client) ListBeers(query ListQuery) *BeerIterator {
+	return &BeerIterator{client: c, query: query}
+}
+
+//GetBoxPrice price of a box of quantity beers of ID in currency, quantity 0 lets the API use its default
+func (c *Client) GetBoxPrice(ctx context.Context, ID int, currency string, quantity int) (BoxPrice, error) {
+	query := url.Values{"currency": {currency}}
+	if quantity > 0 {
+		query.Set("quantity", strconv.Itoa(quantity))
+	}
+	var price BoxPrice
+	err := c.do(ctx, http.MethodGet, routes.BeerPath(routes.BoxPrice, ID), query, nil, &price)
+	return price, err
+}
+
+//BeerIterator reads the pages of ListBeers one at a time
+//
+//	it := c.ListBeers(client.ListQuery{Country: "Colombia"})
+//	for it.Next(ctx) {
+//		beer := it.Beer()
+//	}
+//	if err := it.Err(); err != nil {
+type BeerIterator struct {
+	client *Client
+	query  ListQuery
+	page   []model.Beer
+	index  int
+	cursor string
+	last   bool
+	err    error
+}
+
+//Next moves to the next beer reading another page when the current one is over, it returns false
+//at the end or on the first error
+func (it *BeerIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.page) {
+		if it.last {
+			return false
+		}
+		page, err := it.client.ListPage(ctx, it.query, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.index, it.cursor = page.Items, 0, page.NextCursor
+		it.last = page.NextCursor == ""
+	}
+	return true
+}
+
+//Beer current beer of the iteration
+func (it *BeerIterator) Beer() model.Beer {
+	return it.page[it.index]
+}
+
+//Err error that stopped the iteration, nil when every page was read
+func (it *BeerIterator) Err() error {
+	return it.err
+}
+
+//do sends the request and decodes a successful body into out, GET requests are retried with
+//exponential backoff after network errors and 502, 503 or 504
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body []byte, out interface{}) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	attempts := 1
+	if method == http.MethodGet {
+		attempts += c.retries
+	}
+	backoff := c.backoff
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := wait(ctx, backoff); err != nil {
+				return err
+			}
+			backoff *= 2
+		}
+
+		var retry bool
+		retry, err = c.send(ctx, method, target, body, out)
+		if !retry {
+			return err
+		}
+	}
+	return err
+}
+
+//send makes one attempt of a request, retry tells if it failed in a way worth another attempt
+func (c *Client) send(ctx context.Context, method, target string, body []byte, out interface{}) (bool, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if ID := lib.CorrelationIDFromContext(ctx); ID != "" {
+		request.Header.Set(lib.CorrelationHeader, ID)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return ctx.Err() == nil, errors.FromContext(ctx, err)
+	}
+	defer response.Body.Close()
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return true, err
+	}
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return retryable(response.StatusCode), newAPIError(response.StatusCode, data)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return false, fmt.Errorf("decoding %s %s: %w", method, target, err)
+	}
+	return false, nil
+}
+
+//retryable indicates if a status may go away sending the request again
+func retryable(status int) bool {
+	return status == http.StatusBadGateway ||
+		status == http.StatusServiceUnavailable ||
+		status == http.StatusGatewayTimeout
+}
+
+//wait sleeps d unless ctx is done first
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return errors.FromContext(ctx, ctx.Err())
+	}
+}
+
+//New construct for Client
+func New(config Config) *Client {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	retries := config.Retries
+	switch {
+	case retries == 0:
+		retries = defaultRetries
+	case retries < 0:
+		retries = 0
+	}
+	backoff := config.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(config.BaseURL, "/"),
+		httpClient: httpClient,
+		retries:    retries,
+		backoff:    backoff,
+	}
+}
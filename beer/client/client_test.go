@@ -0,0 +1,233 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	boxprice "github.com/chandy20/prueba-smartjobandina/beer/box-price/v1/api"
+	create "github.com/chandy20/prueba-smartjobandina/beer/create/v1/api"
+	"github.com/chandy20/prueba-smartjobandina/beer/exchange"
+	find "github.com/chandy20/prueba-smartjobandina/beer/find/v1/api"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/middleware"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/routes"
+	list "github.com/chandy20/prueba-smartjobandina/beer/list/v1/api"
+	"github.com/chandy20/prueba-smartjobandina/beer/localapi"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+//newTestServer serves the create, find, list and box-price handlers over store like localapi does
+func newTestServer(t *testing.T, store repository.Beers) *httptest.Server {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	rates := &exchange.StaticRates{Base: "USD", Rates: map[string]float64{"COP": 4000}}
+	standard := middleware.Standard(logger)
+
+	router := localapi.NewRouter([]localapi.Route{
+		{Method: http.MethodPost, Pattern: routes.Beers, Handler: middleware.Chain(create.NewHandler(store, logger), standard...)},
+		{Method: http.MethodGet, Pattern: routes.Beers, Handler: middleware.Chain(list.NewHandler(store, lib.NewCursor([]byte("secret")), logger), standard...)},
+		{Method: http.MethodGet, Pattern: routes.Beer, Handler: middleware.Chain(find.NewHandler(store, logger), standard...)},
+		{Method: http.MethodGet, Pattern: routes.BoxPrice, Handler: middleware.Chain(boxprice.NewHandler(store, rates, logger), standard...)},
+	}, logger)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+//newBeersStore memory repository holding beers
+func newBeersStore(t *testing.T, beers ...model.Beer) *repository.MemoryRepository {
+	store := repository.NewMemoryRepository()
+	for _, beer := range beers {
+		if err := store.Save(context.Background(), beer); err != nil {
+			t.Fatalf("error saving beer %v", err)
+		}
+	}
+	return store
+}
+
+func TestClient_CreateBeerAndGetBeer(t *testing.T) {
+	server := newTestServer(t, newBeersStore(t))
+	c := New(Config{BaseURL: server.URL})
+	ctx := context.Background()
+
+	pilsen := model.Beer{
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    money.NewDecimal(2400, 0),
+		Currency: "COP",
+	}
+	created, err := c.CreateBeer(ctx, pilsen)
+	if err != nil {
+		t.Fatalf("CreateBeer() error = %v", err)
+	}
+	pilsen.ID, pilsen.Version = 1, 1
+	if diff := cmp.Diff(pilsen, created); diff != "" {
+		t.Errorf("CreateBeer() (-want,+got)\n%s", diff)
+	}
+
+	got, err := c.GetBeer(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetBeer() error = %v", err)
+	}
+	if diff := cmp.Diff(pilsen, got); diff != "" {
+		t.Errorf("GetBeer() (-want,+got)\n%s", diff)
+	}
+
+	_, err = c.CreateBeer(ctx, pilsen)
+	if !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Errorf("CreateBeer() of a taken ID must be ErrAlreadyExists but is %v", err)
+	}
+}
+
+func TestClient_errors(t *testing.T) {
+	server := newTestServer(t, newBeersStore(t))
+	c := New(Config{BaseURL: server.URL})
+	ctx := context.Background()
+
+	_, err := c.GetBeer(ctx, 99)
+	if !errors.Is(err, repository.ErrBeerNotFound) {
+		t.Errorf("GetBeer() of a missing beer must be ErrBeerNotFound but is %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Problem.Instance != "/v1/99" {
+		t.Errorf("GetBeer() error must be an *APIError with the problem, got %#v", err)
+	}
+
+	_, err = c.CreateBeer(ctx, model.Beer{Name: "P", Brewery: "Bavaria", Country: "Colombia", Price: money.NewDecimal(1, 0), Currency: "ABC"})
+	want := []errors.Field{
+		{Pointer: "/currency", Code: "currency_not_supported"},
+		{Pointer: "/name", Code: "string_gte", Detail: "String length must be greater than or equal to 2"},
+	}
+	if diff := cmp.Diff(want, errors.FieldsOf(err)); diff != "" {
+		t.Errorf("CreateBeer() fields (-want,+got)\n%s", diff)
+	}
+	if errors.Status(err) != http.StatusBadRequest || errors.CodeOf(err) != "beer_is_not_valid" {
+		t.Errorf("CreateBeer() error = %v, want a beer_is_not_valid validation error", err)
+	}
+}
+
+func TestClient_ListBeers(t *testing.T) {
+	var beers []model.Beer
+	for ID := 1; ID <= 5; ID++ {
+		beers = append(beers, model.Beer{
+			ID:       ID,
+			Name:     "Beer " + strconv.Itoa(ID),
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(int64(ID*1000), 0),
+			Currency: "COP",
+			Version:  1,
+		})
+	}
+	beers = append(beers, model.Beer{ID: 6, Name: "Corona", Brewery: "Modelo", Country: "Mexico", Price: money.NewDecimal(20, 0), Currency: "MXN"})
+	server := newTestServer(t, newBeersStore(t, beers...))
+	c := New(Config{BaseURL: server.URL})
+
+	var got []model.Beer
+	it := c.ListBeers(ListQuery{Country: "Colombia", Limit: 2})
+	for it.Next(context.Background()) {
+		got = append(got, it.Beer())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("ListBeers() error = %v", err)
+	}
+	if diff := cmp.Diff(beers[:5], got); diff != "" {
+		t.Errorf("ListBeers() (-want,+got)\n%s", diff)
+	}
+
+	it = c.ListBeers(ListQuery{Sort: "cheapest"})
+	if it.Next(context.Background()) {
+		t.Errorf("ListBeers() with a sort that is not valid must not return beers")
+	}
+	if errors.CodeOf(it.Err()) != "sort_is_not_valid" {
+		t.Errorf("ListBeers() error = %v, want sort_is_not_valid", it.Err())
+	}
+}
+
+func TestClient_GetBoxPrice(t *testing.T) {
+	server := newTestServer(t, newBeersStore(t, model.Beer{
+		ID:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    money.NewDecimal(2400, 0),
+		Currency: "COP",
+	}))
+	c := New(Config{BaseURL: server.URL})
+
+	got, err := c.GetBoxPrice(context.Background(), 1, "USD", 6)
+	if err != nil {
+		t.Fatalf("GetBoxPrice() error = %v", err)
+	}
+	want := BoxPrice{
+		PriceTotal: money.NewDecimal(360, 2),
+		Currency:   "USD",
+		UnitPrice:  money.NewDecimal(60, 2),
+		Quantity:   6,
+		Rate:       0.00025,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetBoxPrice() (-want,+got)\n%s", diff)
+	}
+}
+
+func TestClient_retries(t *testing.T) {
+	var (
+		mutex        sync.Mutex
+		attempts     = map[string]int{}
+		correlations []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		attempts[r.Method]++
+		attempt := attempts[r.Method]
+		correlations = append(correlations, r.Header.Get(lib.CorrelationHeader))
+		mutex.Unlock()
+
+		if attempt < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message":"Service Unavailable"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1,"name":"Pilsen","brewery":"Bavaria","country":"Colombia","price":2400,"currency":"COP"}`))
+	}))
+	defer server.Close()
+
+	c := New(Config{BaseURL: server.URL, Backoff: time.Millisecond})
+	ctx := lib.WithCorrelationID(context.Background(), "client-id")
+
+	beer, err := c.GetBeer(ctx, 1)
+	if err != nil || beer.ID != 1 {
+		t.Errorf("GetBeer() = %v, %v, want the beer after retrying", beer, err)
+	}
+	if attempts[http.MethodGet] != 3 {
+		t.Errorf("GetBeer() attempts = %d, want 3", attempts[http.MethodGet])
+	}
+	for _, ID := range correlations {
+		if ID != "client-id" {
+			t.Errorf("request sent with correlation ID %q, want client-id", ID)
+		}
+	}
+
+	_, err = c.CreateBeer(ctx, model.Beer{Name: "Pilsen"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable || apiErr.Problem.Detail != "Service Unavailable" {
+		t.Errorf("CreateBeer() error = %#v, want the 503 of the gateway", err)
+	}
+	if attempts[http.MethodPost] != 1 {
+		t.Errorf("CreateBeer() attempts = %d, it must not be retried", attempts[http.MethodPost])
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+)
+
+//problemTypePrefix prefix of the type of the problems answered by the API
+const problemTypePrefix = "/problems/"
+
+//APIError error answered by the API with its problem body. It unwraps to the domain error of its
+//status and code so errors.Is(err, repository.ErrBeerNotFound) works on both sides of the wire
+type APIError struct {
+	StatusCode int
+	Problem    lib.Problem
+}
+
+//Error status and code of the problem
+func (e *APIError) Error() string {
+	return fmt.Sprintf("beer api responded %d: %s", e.StatusCode, e.Problem.Detail)
+}
+
+//Unwrap domain error of the problem
+func (e *APIError) Unwrap() error {
+	code := strings.TrimPrefix(e.Problem.Type, problemTypePrefix)
+	if code == "" || code == e.Problem.Type {
+		code = e.Problem.Detail
+	}
+	return &errors.Error{
+		Kind:   errors.KindOfStatus(e.StatusCode),
+		Code:   code,
+		Fields: e.Problem.Errors,
+	}
+}
+
+//newAPIError decodes the body of an error response, bodies that are not problems, like the ones of
+//API Gateway, keep their message as detail
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: status}
+	if err := json.Unmarshal(body, &apiErr.Problem); err == nil && apiErr.Problem.Type != "" {
+		return apiErr
+	}
+
+	var gateway struct {
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &gateway)
+	apiErr.Problem = lib.Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: gateway.Message,
+	}
+	return apiErr
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+)
+
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+//fields attributes of a beer in the order they are written
+var fields = []string{"id", "name", "brewery", "country", "price", "currency"}
+
+//record beer read from a file, line is where it starts to report errors
+type record struct {
+	line   int
+	fields map[string]string
+}
+
+//columns names of the fields of a beer in the file
+type columns map[string]string
+
+//parseColumns reads a mapping like "name=nombre,price=precio", fields left out keep their name
+func parseColumns(mapping string) (columns, error) {
+	cols := columns{}
+	for _, field := range fields {
+		cols[field] = field
+	}
+	if strings.TrimSpace(mapping) == "" {
+		return cols, nil
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("mapping %q must look like field=column", pair)
+		}
+		field := strings.TrimSpace(parts[0])
+		if _, ok := cols[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q, fields are %s", field, strings.Join(fields, ", "))
+		}
+		cols[field] = strings.TrimSpace(parts[1])
+	}
+	return cols, nil
+}
+
+//fromColumns renames the columns of a row to the fields of a beer, unknown columns are dropped
+func (c columns) fromColumns(row map[string]string) map[string]string {
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if value, ok := row[c[field]]; ok {
+			values[field] = value
+		}
+	}
+	return values
+}
+
+//detectFormat takes the format from the flag or else from the extension of the file
+func detectFormat(format, path string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = formatCSV
+		case ".ndjson", ".jsonl":
+			format = formatNDJSON
+		default:
+			return "", errors.New("can not detect the format, use -format csv or -format ndjson")
+		}
+	}
+	if format != formatCSV && format != formatNDJSON {
+		return "", fmt.Errorf("format %q is not supported, use csv or ndjson", format)
+	}
+	return format, nil
+}
+
+//readRecords reads every beer of a CSV with header or of a file with one JSON object by line
+func readRecords(r io.Reader, format string, cols columns) ([]record, error) {
+	if format == formatCSV {
+		return readCSV(r, cols)
+	}
+	return readNDJSON(r, cols)
+}
+
+//readCSV reads a CSV whose first row names the columns
+func readCSV(r io.Reader, cols columns) ([]record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return []record{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	records := []record{}
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(values) {
+				row[column] = strings.TrimSpace(values[i])
+			}
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record{line: line, fields: cols.fromColumns(row)})
+	}
+}
+
+//readNDJSON reads one JSON object by line skipping blank lines
+func readNDJSON(r io.Reader, cols columns) ([]record, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	records := []record{}
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(text, &object); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		row := make(map[string]string, len(object))
+		for key, raw := range object {
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				value = string(raw)
+			}
+			row[key] = value
+		}
+		records = append(records, record{line: line, fields: cols.fromColumns(row)})
+	}
+	return records, scanner.Err()
+}
+
+//toBeer converts the fields of a record to a beer, it does not validate it
+func (r record) toBeer() (model.Beer, error) {
+	beer := model.Beer{
+		Name:     r.fields["name"],
+		Brewery:  r.fields["brewery"],
+		Country:  r.fields["country"],
+		Currency: r.fields["currency"],
+	}
+
+	ID, err := strconv.Atoi(r.fields["id"])
+	if err != nil {
+		return model.Beer{}, errors.New("beerID_is_not_a_number")
+	}
+	if ID <= 0 {
+		return model.Beer{}, errors.New("beerID_is_required")
+	}
+	beer.ID = ID
+
+	price, err := money.ParseDecimal(r.fields["price"])
+	if err != nil {
+		return model.Beer{}, errors.New("price_is_not_a_number")
+	}
+	beer.Price = price
+
+	return beer, nil
+}
+
+//recordWriter writes beers in a format
+type recordWriter interface {
+	Write(model.Beer) error
+	Flush() error
+}
+
+//newRecordWriter builds the writer of the format, CSV writes its header right away
+func newRecordWriter(w io.Writer, format string, cols columns) (recordWriter, error) {
+	if format == formatNDJSON {
+		return &ndjsonWriter{w: bufio.NewWriter(w), cols: cols}, nil
+	}
+
+	writer := &csvWriter{w: csv.NewWriter(w)}
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = cols[field]
+	}
+	return writer, writer.w.Write(header)
+}
+
+//csvWriter writes one beer by row
+type csvWriter struct {
+	w *csv.Writer
+}
+
+//Write writes the beer as a row
+func (c *csvWriter) Write(beer model.Beer) error {
+	return c.w.Write([]string{
+		strconv.Itoa(beer.ID),
+		beer.Name,
+		beer.Brewery,
+		beer.Country,
+		beer.Price.String(),
+		beer.Currency,
+	})
+}
+
+//Flush writes the buffered rows
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+//ndjsonWriter writes one JSON object by line with the keys in the order of fields
+type ndjsonWriter struct {
+	w    *bufio.Writer
+	cols columns
+}
+
+//Write writes the beer as a line
+func (n *ndjsonWriter) Write(beer model.Beer) error {
+	values := map[string]interface{}{
+		"id":       beer.ID,
+		"name":     beer.Name,
+		"brewery":  beer.Brewery,
+		"country":  beer.Country,
+		"price":    beer.Price,
+		"currency": beer.Currency,
+	}
+
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		key, err := json.Marshal(n.cols[field])
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(values[field])
+		if err != nil {
+			return err
+		}
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+
+	_, err := n.w.Write(line.Bytes())
+	return err
+}
+
+//Flush writes the buffered lines
+func (n *ndjsonWriter) Flush() error {
+	return n.w.Flush()
+}
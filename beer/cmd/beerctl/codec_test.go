@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+)
+
+func Test_parseColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping string
+		want    columns
+		wantErr bool
+	}{
+		{
+			name:    "should_keep_field_names_without_mapping",
+			mapping: "",
+			want: columns{
+				"id": "id", "name": "name", "brewery": "brewery",
+				"country": "country", "price": "price", "currency": "currency",
+			},
+		},
+		{
+			name:    "should_rename_mapped_fields",
+			mapping: "name=nombre, price=precio",
+			want: columns{
+				"id": "id", "name": "nombre", "brewery": "brewery",
+				"country": "country", "price": "precio", "currency": "currency",
+			},
+		},
+		{
+			name:    "should_fail_because_field_does_not_exist",
+			mapping: "abv=alcohol",
+			wantErr: true,
+		},
+		{
+			name:    "should_fail_because_mapping_has_no_column",
+			mapping: "name",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseColumns(tt.mapping)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseColumns() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseColumns() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readRecords(t *testing.T) {
+	cols, _ := parseColumns("name=nombre,price=precio")
+	want := []record{
+		{line: 2, fields: map[string]string{
+			"id": "1", "name": "Águila", "brewery": "Bavaria", "country": "Colombia", "price": "2400", "currency": "COP",
+		}},
+		{line: 3, fields: map[string]string{
+			"id": "2", "name": "Corona, Extra", "brewery": "Modelo", "country": "Mexico", "price": "20.5", "currency": "MXN",
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{
+			name:   "should_read_csv_with_mapped_columns",
+			format: formatCSV,
+			input: "id,nombre,brewery,country,precio,currency,notes\n" +
+				"1,Águila,Bavaria,Colombia,2400,COP,\n" +
+				"2,\"Corona, Extra\",Modelo,Mexico,20.5,MXN,imported\n",
+		},
+		{
+			name:   "should_read_ndjson_with_mapped_keys",
+			format: formatNDJSON,
+			input: "\n" +
+				`{"id":1,"nombre":"Águila","brewery":"Bavaria","country":"Colombia","precio":2400,"currency":"COP"}` + "\n" +
+				`{"id":2,"nombre":"Corona, Extra","brewery":"Modelo","country":"Mexico","precio":20.5,"currency":"MXN"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readRecords(strings.NewReader(tt.input), tt.format, cols)
+			if err != nil {
+				t.Errorf("readRecords() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("readRecords() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_newRecordWriter(t *testing.T) {
+	cols, _ := parseColumns("price=precio")
+	beer := model.Beer{
+		ID:       2,
+		Name:     "Corona, Extra",
+		Brewery:  "Modelo",
+		Country:  "Mexico",
+		Price:    money.NewDecimal(205, 1),
+		Currency: "MXN",
+		Version:  3,
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "should_write_csv_with_header",
+			format: formatCSV,
+			want:   "id,name,brewery,country,precio,currency\n2,\"Corona, Extra\",Modelo,Mexico,20.5,MXN\n",
+		},
+		{
+			name:   "should_write_ndjson_in_field_order",
+			format: formatNDJSON,
+			want:   `{"id":2,"name":"Corona, Extra","brewery":"Modelo","country":"Mexico","precio":20.5,"currency":"MXN"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			writer, err := newRecordWriter(&out, tt.format, cols)
+			if err != nil {
+				t.Errorf("newRecordWriter() error = %v", err)
+				return
+			}
+			if err = writer.Write(beer); err != nil {
+				t.Errorf("Write() error = %v", err)
+			}
+			if err = writer.Flush(); err != nil {
+				t.Errorf("Flush() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("newRecordWriter() wrote %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+)
+
+//exportChunk beers written between progress reports
+const exportChunk = 100
+
+//exportStore operations of the repository used to export
+type exportStore interface {
+	List(context.Context) ([]model.Beer, error)
+}
+
+//runExport parses the flags of the export command and writes the catalog
+func runExport(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var conn connection
+	conn.bind(flags)
+	file := flags.String("file", "-", "file to write, - writes stdout")
+	format := flags.String("format", "", "csv or ndjson, detected from the file extension when empty")
+	mapping := flags.String("map", "", "columns of the file by field, for example name=nombre,price=precio")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	detected, err := detectFormat(*format, *file)
+	if err != nil {
+		return err
+	}
+	cols, err := parseColumns(*mapping)
+	if err != nil {
+		return err
+	}
+
+	repo, err := conn.repository(stderr)
+	if err != nil {
+		return err
+	}
+
+	if *file == "-" {
+		return exportBeers(context.Background(), repo, stdout, detected, cols, stderr)
+	}
+
+	f, err := os.Create(*file)
+	if err != nil {
+		return err
+	}
+	if err = exportBeers(context.Background(), repo, f, detected, cols, stderr); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+//exportBeers writes every active beer reporting the progress
+func exportBeers(ctx context.Context, store exportStore, output io.Writer, format string, cols columns, progress io.Writer) error {
+	beers, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	writer, err := newRecordWriter(output, format, cols)
+	if err != nil {
+		return err
+	}
+	for i, beer := range beers {
+		if err = writer.Write(beer); err != nil {
+			return err
+		}
+		if (i+1)%exportChunk == 0 {
+			fmt.Fprintf(progress, "exported %d/%d\n", i+1, len(beers))
+		}
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(progress, "exported %d beers\n", len(beers))
+	return nil
+}
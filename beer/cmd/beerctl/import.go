@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+)
+
+//importChunk records validated and saved together before reporting progress
+const importChunk = 100
+
+//importStore operations of the repository used to import
+type importStore interface {
+	FindMany(context.Context, []int) (map[int]model.Beer, error)
+	SaveBatch(context.Context, []model.Beer) ([]int, []int, error)
+}
+
+//importReport totals of an import
+type importReport struct {
+	Created  int
+	Conflict int
+	Invalid  int
+	Failed   int
+}
+
+//runImport parses the flags of the import command and imports the file
+func runImport(args []string, stdin io.Reader, stderr io.Writer) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var conn connection
+	conn.bind(flags)
+	file := flags.String("file", "-", "file to read, - reads stdin")
+	format := flags.String("format", "", "csv or ndjson, detected from the file extension when empty")
+	mapping := flags.String("map", "", "columns of the file by field, for example name=nombre,price=precio")
+	dryRun := flags.Bool("dry-run", false, "validate and look for conflicts without saving")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	detected, err := detectFormat(*format, *file)
+	if err != nil {
+		return err
+	}
+	cols, err := parseColumns(*mapping)
+	if err != nil {
+		return err
+	}
+
+	input := stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	records, err := readRecords(input, detected, cols)
+	if err != nil {
+		return err
+	}
+
+	repo, err := conn.repository(stderr)
+	if err != nil {
+		return err
+	}
+
+	report, err := importRecords(context.Background(), records, repo, *dryRun, stderr)
+	if err != nil {
+		return err
+	}
+	if report.Invalid > 0 || report.Failed > 0 {
+		return fmt.Errorf("%d invalid and %d failed beers", report.Invalid, report.Failed)
+	}
+	return nil
+}
+
+//importRecords validates the records and saves the new beers in chunks, reporting every problem
+//with the line of the file and the progress after each chunk. Beers are only written when their ID
+//is free, a dry run looks for the taken IDs instead
+func importRecords(ctx context.Context, records []record, store importStore, dryRun bool, progress io.Writer) (importReport, error) {
+	var report importReport
+	seen := map[int]int{}
+
+	for start := 0; start < len(records); start += importChunk {
+		end := start + importChunk
+		if end > len(records) {
+			end = len(records)
+		}
+
+		var beers []model.Beer
+		var IDs []int
+		lines := map[int]int{}
+		for _, rec := range records[start:end] {
+			beer, err := rec.toBeer()
+			if err == nil {
+				err = lib.ValidateBeer(&beer)
+			}
+			if err != nil {
+				report.Invalid++
+				fmt.Fprintf(progress, "line %d: invalid: %v\n", rec.line, err)
+				continue
+			}
+			if line, ok := seen[beer.ID]; ok {
+				report.Conflict++
+				fmt.Fprintf(progress, "line %d: conflict: beer %d is repeated from line %d\n", rec.line, beer.ID, line)
+				continue
+			}
+			seen[beer.ID] = rec.line
+			lines[beer.ID] = rec.line
+			beers = append(beers, beer)
+			IDs = append(IDs, beer.ID)
+		}
+
+		if dryRun && len(IDs) > 0 {
+			existing, err := store.FindMany(ctx, IDs)
+			if err != nil {
+				return report, err
+			}
+			for _, beer := range beers {
+				if _, ok := existing[beer.ID]; ok {
+					report.Conflict++
+					fmt.Fprintf(progress, "line %d: conflict: beer %d already exists\n", lines[beer.ID], beer.ID)
+					continue
+				}
+				report.Created++
+			}
+		} else if len(beers) > 0 {
+			conflicts, unprocessed, err := store.SaveBatch(ctx, beers)
+			for _, ID := range conflicts {
+				report.Conflict++
+				fmt.Fprintf(progress, "line %d: conflict: beer %d already exists\n", lines[ID], ID)
+			}
+			for _, ID := range unprocessed {
+				report.Failed++
+				fmt.Fprintf(progress, "line %d: failed: beer %d was not saved\n", lines[ID], ID)
+			}
+			if err != nil {
+				return report, err
+			}
+			report.Created += len(beers) - len(conflicts) - len(unprocessed)
+		}
+
+		fmt.Fprintf(progress, "processed %d/%d\n", end, len(records))
+	}
+
+	verb := "created"
+	if dryRun {
+		verb = "would create"
+	}
+	fmt.Fprintf(progress, "%s %d, conflict %d, invalid %d, failed %d\n",
+		verb, report.Created, report.Conflict, report.Invalid, report.Failed)
+	return report, nil
+}
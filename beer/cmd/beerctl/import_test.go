@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/stretchr/testify/mock"
+)
+
+//storeMock mock for represent the beers repository
+type storeMock struct {
+	mock.Mock
+}
+
+func (s *storeMock) FindMany(_ context.Context, IDs []int) (map[int]model.Beer, error) {
+	args := s.Called(IDs)
+	return args.Get(0).(map[int]model.Beer), args.Error(1)
+}
+
+func (s *storeMock) SaveBatch(_ context.Context, beers []model.Beer) ([]int, []int, error) {
+	args := s.Called(beers)
+	return args.Get(0).([]int), args.Get(1).([]int), args.Error(2)
+}
+
+func (s *storeMock) List(_ context.Context) ([]model.Beer, error) {
+	args := s.Called()
+	return args.Get(0).([]model.Beer), args.Error(1)
+}
+
+func Test_importRecords(t *testing.T) {
+	input := "id,name,brewery,country,price,currency\n" +
+		"1,Pilsen,Bavaria,Colombia,2400,cop\n" +
+		"2,Poker,Bavaria,Colombia,2000,COP\n" +
+		"1,Pilsen Light,Bavaria,Colombia,2400,COP\n" +
+		"x,Club Colombia,Bavaria,Colombia,3000,COP\n" +
+		"3,Corona,Modelo,Mexico,20.555,MXN\n" +
+		"0,Aguila,Bavaria,Colombia,2000,COP\n"
+	cols, _ := parseColumns("")
+	records, err := readRecords(strings.NewReader(input), formatCSV, cols)
+	if err != nil {
+		t.Fatalf("error reading records %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		dryRun       bool
+		mocker       func(m *storeMock)
+		want         importReport
+		wantProgress []string
+	}{
+		{
+			name:   "should_save_only_new_valid_beers",
+			dryRun: false,
+			mocker: func(m *storeMock) {
+				m.On("SaveBatch", mock.MatchedBy(func(beers []model.Beer) bool {
+					return len(beers) == 2 && beers[0].ID == 1 && beers[0].Currency == "COP" && beers[1].ID == 2
+				})).Return([]int{2}, []int(nil), nil).Once()
+			},
+			want: importReport{Created: 1, Conflict: 2, Invalid: 3},
+			wantProgress: []string{
+				"line 3: conflict: beer 2 already exists",
+				"line 4: conflict: beer 1 is repeated from line 2",
+				"line 5: invalid: beerID_is_not_a_number",
+				"line 6: invalid: beer_is_not_valid: /price price_exceeds_currency_precision",
+				"line 7: invalid: beerID_is_required",
+				"processed 6/6",
+				"created 1, conflict 2, invalid 3, failed 0",
+			},
+		},
+		{
+			name:   "should_not_save_on_dry_run",
+			dryRun: true,
+			mocker: func(m *storeMock) {
+				m.On("FindMany", []int{1, 2}).Return(map[int]model.Beer{}, nil).Once()
+			},
+			want: importReport{Created: 2, Conflict: 1, Invalid: 3},
+			wantProgress: []string{
+				"would create 2, conflict 1, invalid 3, failed 0",
+			},
+		},
+		{
+			name:   "should_report_beers_left_unprocessed",
+			dryRun: false,
+			mocker: func(m *storeMock) {
+				m.On("SaveBatch", mock.Anything).Return([]int(nil), []int{2}, nil).Once()
+			},
+			want: importReport{Created: 1, Conflict: 1, Invalid: 3, Failed: 1},
+			wantProgress: []string{
+				"line 3: failed: beer 2 was not saved",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &storeMock{}
+			tt.mocker(store)
+			var progress bytes.Buffer
+			got, err := importRecords(context.Background(), records, store, tt.dryRun, &progress)
+			if err != nil {
+				t.Errorf("importRecords() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("importRecords() got = %+v, want %+v", got, tt.want)
+			}
+			for _, line := range tt.wantProgress {
+				if !strings.Contains(progress.String(), line+"\n") {
+					t.Errorf("importRecords() progress %q does not have %q", progress.String(), line)
+				}
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_exportBeers(t *testing.T) {
+	store := &storeMock{}
+	store.On("List").Return([]model.Beer{{ID: 1, Name: "Pilsen", Currency: "COP"}}, nil).Once()
+	cols, _ := parseColumns("")
+
+	var out, progress bytes.Buffer
+	err := exportBeers(context.Background(), store, &out, formatNDJSON, cols, &progress)
+	if err != nil {
+		t.Errorf("exportBeers() error = %v", err)
+	}
+	want := `{"id":1,"name":"Pilsen","brewery":"","country":"","price":0,"currency":"COP"}` + "\n"
+	if out.String() != want {
+		t.Errorf("exportBeers() wrote %q, want %q", out.String(), want)
+	}
+	if progress.String() != "exported 1 beers\n" {
+		t.Errorf("exportBeers() progress %q", progress.String())
+	}
+	store.AssertExpectations(t)
+}
+
+func Test_run(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"drop"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("run() of an unknown command must exit with 2 but exit with %v", code)
+	}
+	if code := run([]string{"import", "-file", "beers.xml"}, strings.NewReader(""), &stdout, &stderr); code != 1 {
+		t.Errorf("run() of a file without format must exit with 1 but exit with %v", code)
+	}
+}
@@ -0,0 +1,100 @@
+//beerctl imports and exports the beer catalog of a dynamodb table in CSV or NDJSON.
+//
+//	beerctl import -table beers -file beers.csv -map name=nombre,price=precio -dry-run
+//	beerctl export -table beers -endpoint http://localhost:8000 -format ndjson > beers.ndjson
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const usage = `usage: beerctl <command> [flags]
+
+commands:
+  import   read beers from a CSV or NDJSON file and save the new ones
+  export   write every active beer to a CSV or NDJSON file
+
+run "beerctl <command> -h" to see the flags of a command`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+//run executes a command and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "import":
+		err = runImport(args[1:], stdin, stderr)
+	case "export":
+		err = runExport(args[1:], stdout, stderr)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprintln(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n%s\n", args[0], usage)
+		return 2
+	}
+
+	if err == flag.ErrHelp {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "beerctl:", err)
+		return 1
+	}
+	return 0
+}
+
+//connection flags to reach the table, the endpoint allows pointing to dynamodb-local
+type connection struct {
+	endpoint string
+	region   string
+	table    string
+}
+
+//bind adds the connection flags to a command
+func (c *connection) bind(flags *flag.FlagSet) {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+	flags.StringVar(&c.endpoint, "endpoint", "", "dynamodb endpoint, for example http://localhost:8000")
+	flags.StringVar(&c.region, "region", region, "aws region")
+	flags.StringVar(&c.table, "table", os.Getenv("DYNAMODB_BEERS"), "beers table, defaults to $DYNAMODB_BEERS")
+}
+
+//repository builds the beer repository of the table, it only logs warnings so progress stays readable
+func (c *connection) repository(stderr io.Writer) (*repository.BeerRepository, error) {
+	if c.table == "" {
+		return nil, fmt.Errorf("-table is required when DYNAMODB_BEERS is not defined")
+	}
+
+	config := aws.NewConfig().WithRegion(c.region)
+	if c.endpoint != "" {
+		config = config.WithEndpoint(c.endpoint)
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(stderr)
+	logger.SetLevel(logrus.WarnLevel)
+	return repository.NewBeerRepository(dynamodb.New(sess), c.table, logger), nil
+}
@@ -0,0 +1,181 @@
+//localapi serves every beer lambda on a local HTTP server so the API runs without AWS.
+//The handlers read the same variables as in AWS, the flags fill the ones needed to reach dynamodb-local.
+//
+//	docker run -p 8000:8000 amazon/dynamodb-local
+//	localapi -addr :3000 -endpoint http://localhost:8000 -table beers
+//	curl localhost:3000/v1/1
+//
+//With -memory the beers are kept in memory instead, nothing else has to be running and they are lost on exit.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	boxprice "github.com/chandy20/prueba-smartjobandina/beer/box-price/v1/api"
+	createbatch "github.com/chandy20/prueba-smartjobandina/beer/create-batch/v1/api"
+	create "github.com/chandy20/prueba-smartjobandina/beer/create/v1/api"
+	deletebeer "github.com/chandy20/prueba-smartjobandina/beer/delete/v1/api"
+	"github.com/chandy20/prueba-smartjobandina/beer/exchange"
+	find "github.com/chandy20/prueba-smartjobandina/beer/find/v1/api"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/middleware"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/openapi"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/routes"
+	listbybrewery "github.com/chandy20/prueba-smartjobandina/beer/list-by-brewery/v1/api"
+	listbycountry "github.com/chandy20/prueba-smartjobandina/beer/list-by-country/v1/api"
+	list "github.com/chandy20/prueba-smartjobandina/beer/list/v1/api"
+	"github.com/chandy20/prueba-smartjobandina/beer/localapi"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	search "github.com/chandy20/prueba-smartjobandina/beer/search/v1/api"
+	update "github.com/chandy20/prueba-smartjobandina/beer/update/v1/api"
+	"github.com/sirupsen/logrus"
+)
+
+//lambdas constructors of the handlers mounted by localapi on the routes of their name
+var lambdas = map[string]func() (lib.HandlerFunc, error){
+	"list":            list.New,
+	"create":          create.New,
+	"create-batch":    createbatch.New,
+	"search":          search.New,
+	"find":            find.New,
+	"update":          update.New,
+	"delete":          deletebeer.New,
+	"box-price":       boxprice.New,
+	"list-by-brewery": listbybrewery.New,
+	"list-by-country": listbycountry.New,
+	"openapi":         func() (lib.HandlerFunc, error) { return openapi.Handler, nil },
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+//run starts the server and returns the exit code once it stops
+func run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("localapi", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	addr := flags.String("addr", ":3000", "address the server listens on")
+	endpoint := flags.String("endpoint", "http://localhost:8000", "dynamodb endpoint, empty to use the one of aws")
+	region := flags.String("region", envOr("AWS_REGION", "us-east-1"), "aws region")
+	table := flags.String("table", envOr("DYNAMODB_BEERS", "beers"), "beers table, defaults to $DYNAMODB_BEERS")
+	memory := flags.Bool("memory", false, "keep the beers in memory instead of dynamodb")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	configure(*endpoint, *region, *table)
+
+	logger := logrus.StandardLogger()
+	var handlers map[string]lib.HandlerFunc
+	if *memory {
+		var err error
+		handlers, err = memoryHandlers(repository.NewMemoryRepository(), logger)
+		if err != nil {
+			fmt.Fprintln(stderr, "localapi:", err)
+			return 1
+		}
+		*endpoint = "memory"
+	}
+	mounted, err := mount(logger, handlers)
+	if err != nil {
+		fmt.Fprintln(stderr, "localapi:", err)
+		return 1
+	}
+
+	logger.WithField("addr", *addr).WithField("endpoint", *endpoint).Info("serving beer api")
+	err = http.ListenAndServe(*addr, localapi.NewRouter(mounted, logger))
+	if err != nil {
+		fmt.Fprintln(stderr, "localapi:", err)
+		return 1
+	}
+	return 0
+}
+
+//configure sets the variables the lambdas read at startup. dynamodb-local accepts any credentials,
+//the ecb rates need no access key and cursors only have to be signed by the same process, so those
+//are set when they are not given
+func configure(endpoint, region, table string) {
+	os.Setenv("DYNAMODB_ENDPOINT", endpoint)
+	os.Setenv("AWS_REGION", region)
+	os.Setenv("DYNAMODB_BEERS", table)
+	if endpoint != "" && os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		os.Setenv("AWS_ACCESS_KEY_ID", "local")
+		os.Setenv("AWS_SECRET_ACCESS_KEY", "local")
+	}
+	if os.Getenv("CURRENCY_PROVIDER") == "" && os.Getenv("ACCESS_KEY_CURRENCY") == "" {
+		os.Setenv("CURRENCY_PROVIDER", "ecb")
+	}
+	if os.Getenv("CURSOR_SECRET") == "" {
+		os.Setenv("CURSOR_SECRET", "local")
+	}
+}
+
+//mount builds every lambda with the standard middleware stack its main uses and mounts it on its
+//routes, the lambdas found in handlers are taken from there instead of being built like in aws
+func mount(logger *logrus.Logger, handlers map[string]lib.HandlerFunc) ([]localapi.Route, error) {
+	built := make(map[string]lib.HandlerFunc, len(lambdas))
+	for name, newHandler := range lambdas {
+		handler, ok := handlers[name]
+		if !ok {
+			var err error
+			handler, err = newHandler()
+			if err != nil {
+				return nil, fmt.Errorf("building %s: %w", name, err)
+			}
+		}
+		built[name] = middleware.Chain(handler, middleware.Standard(logger)...)
+	}
+
+	var mounted []localapi.Route
+	for _, route := range routes.All {
+		handler, ok := built[route.Lambda]
+		if !ok {
+			return nil, fmt.Errorf("lambda %s of %s %s is unknown", route.Lambda, route.Method, route.Pattern)
+		}
+		mounted = append(mounted, localapi.Route{Method: route.Method, Pattern: route.Pattern, Handler: handler})
+	}
+	return mounted, nil
+}
+
+//memoryHandlers the lambdas of the beers table built over beers, the cursors and the currency api
+//read the same variables as in aws and quotes are cached in memory
+func memoryHandlers(beers repository.Beers, logger *logrus.Logger) (map[string]lib.HandlerFunc, error) {
+	converter, err := exchange.New(exchange.Config{
+		Provider:   os.Getenv("CURRENCY_PROVIDER"),
+		AccessKey:  os.Getenv("ACCESS_KEY_CURRENCY"),
+		RatesFile:  os.Getenv("CURRENCY_RATES_FILE"),
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building box-price: %w", err)
+	}
+
+	return map[string]lib.HandlerFunc{
+		"list":            list.NewHandler(beers, lib.NewCursor([]byte(os.Getenv("CURSOR_SECRET"))), logger),
+		"create":          create.NewHandler(beers, logger),
+		"create-batch":    createbatch.NewHandler(beers, logger),
+		"search":          search.NewHandler(beers, logger),
+		"find":            find.NewHandler(beers, logger),
+		"update":          update.NewHandler(beers, logger),
+		"delete":          deletebeer.NewHandler(beers, logger),
+		"box-price":       boxprice.NewHandler(beers, exchange.NewCache(converter, nil, time.Hour), logger),
+		"list-by-brewery": listbybrewery.NewHandler(beers, logger),
+		"list-by-country": listbycountry.NewHandler(beers, logger),
+	}, nil
+}
+
+//envOr value of the variable name, fallback when it is empty
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,173 @@
+package ctx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	"github.com/sirupsen/logrus"
+)
+
+//maxBatchItems max beers accepted in one request
+const maxBatchItems = 500
+
+const (
+	statusCreated  = "created"
+	statusConflict = "conflict"
+	statusInvalid  = "invalid"
+	statusFailed   = "failed"
+)
+
+//errBeerIDRepeated custom error to represent a beer ID used by an earlier item of the batch
+var errBeerIDRepeated = errors.Validation("beerID_repeated_in_batch")
+
+//errBodyIsNotAList custom error to represent a body that is not a json array
+var errBodyIsNotAList = errors.Validation("body_must_be_a_json_list")
+
+//errBeerIDRequired custom error to represent an item without ID, the batch does not allocate them
+var errBeerIDRequired = errors.Validation("beerID_is_required")
+
+//errItemIsNotABeer custom error to represent an item that can not be read as a beer
+var errItemIsNotABeer = errors.Validation("item_is_not_a_beer")
+
+//beerRepositoryInterface contract for beer repository
+type beerRepositoryInterface interface {
+	SaveBatch(context.Context, []model.Beer) ([]int, []int, error)
+}
+
+//itemResult outcome of one beer of the batch, index is its position in the request and errors
+//lists the fields of an invalid beer
+type itemResult struct {
+	Index   int            `json:"index"`
+	ID      int            `json:"id,omitempty"`
+	Status  string         `json:"status"`
+	Message string         `json:"message,omitempty"`
+	Errors  []errors.Field `json:"errors,omitempty"`
+}
+
+//responseLambda report of the batch with the totals by status
+type responseLambda struct {
+	Created  int          `json:"created"`
+	Conflict int          `json:"conflict"`
+	Invalid  int          `json:"invalid"`
+	Failed   int          `json:"failed"`
+	Items    []itemResult `json:"items"`
+}
+
+//Handler main struct for lambda
+type Handler struct {
+	beersRepository beerRepositoryInterface
+	logger          *logrus.Logger
+}
+
+//Handler main function for lambda, every beer is validated on its own so one bad item does not reject the batch.
+//Beers are written only if their ID is free, the taken ones are reported as conflicts
+func (h *Handler) Handler(
+	ctx context.Context,
+	req events.APIGatewayProxyRequest,
+) (events.APIGatewayProxyResponse, error) {
+	logger := lib.Logger(ctx, h.logger).WithField("request_size", len(req.Body))
+	logger.Info("Beginning of execution of lambda")
+
+	var items []json.RawMessage
+	err := json.Unmarshal([]byte(req.Body), &items)
+	if err != nil {
+		logger.WithError(err).Error("error while unmarshalling")
+		return lib.ResponseError(req.Path, errBodyIsNotAList), nil
+	}
+	if len(items) == 0 {
+		return lib.ResponseError(req.Path, errors.Validation("batch_can_not_be_empty")), nil
+	}
+	if len(items) > maxBatchItems {
+		return lib.ResponseError(req.Path, errors.Validation("batch_exceeds_max_items")), nil
+	}
+
+	results := make([]itemResult, len(items))
+	beers := make([]model.Beer, len(items))
+	positions := map[int]int{}
+	var IDs []int
+	for i, item := range items {
+		results[i].Index = i
+
+		err = json.Unmarshal(item, &beers[i])
+		if err != nil {
+			err = errItemIsNotABeer
+		} else if beers[i].ID == 0 {
+			err = errBeerIDRequired
+		} else {
+			err = lib.ValidateBeer(&beers[i])
+		}
+		results[i].ID = beers[i].ID
+		if err != nil {
+			results[i].Status, results[i].Message = statusInvalid, errors.CodeOf(err)
+			results[i].Errors = errors.FieldsOf(err)
+			continue
+		}
+
+		if _, ok := positions[beers[i].ID]; ok {
+			results[i].Status, results[i].Message = statusConflict, errBeerIDRepeated.Error()
+			continue
+		}
+		positions[beers[i].ID] = i
+		IDs = append(IDs, beers[i].ID)
+	}
+
+	var toSave []model.Beer
+	for _, ID := range IDs {
+		i := positions[ID]
+		results[i].Status = statusCreated
+		toSave = append(toSave, beers[i])
+	}
+
+	if len(toSave) > 0 {
+		conflicts, unprocessed, err := h.beersRepository.SaveBatch(ctx, toSave)
+		if err != nil {
+			logger.WithError(err).Error("error saving beers")
+		}
+		for _, ID := range conflicts {
+			i := positions[ID]
+			results[i].Status, results[i].Message = statusConflict, repository.ErrAlreadyExists.Error()
+		}
+		for _, ID := range unprocessed {
+			i := positions[ID]
+			results[i].Status, results[i].Message = statusFailed, errors.CodeOf(err)
+		}
+	}
+
+	report := responseLambda{Items: results}
+	for _, result := range results {
+		switch result.Status {
+		case statusCreated:
+			report.Created++
+		case statusConflict:
+			report.Conflict++
+		case statusInvalid:
+			report.Invalid++
+		case statusFailed:
+			report.Failed++
+		}
+	}
+
+	response, err := json.Marshal(report)
+	if err != nil {
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	return lib.JSONResponse(http.StatusOK, response), nil
+}
+
+//NewHandler construct for Handler
+func NewHandler(
+	beersRepository beerRepositoryInterface,
+	logger *logrus.Logger,
+) *Handler {
+	return &Handler{
+		beersRepository: beersRepository,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,279 @@
+package ctx
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+//beersRepositoryMock mock for represent beers repository
+type beersRepositoryMock struct {
+	mock.Mock
+}
+
+func (b *beersRepositoryMock) SaveBatch(_ context.Context, beers []model.Beer) ([]int, []int, error) {
+	args := b.Called(beers)
+	return args.Get(0).([]int), args.Get(1).([]int), args.Error(2)
+}
+
+//go:embed golden_files/batchRequest.json
+var batchRequest []byte
+
+//go:embed golden_files/reportResponse.json
+var reportResponse []byte
+
+//go:embed golden_files/failedReportResponse.json
+var failedReportResponse []byte
+
+//go:embed golden_files/allFailedReportResponse.json
+var allFailedReportResponse []byte
+
+func TestHandler_Handler(t *testing.T) {
+	headers := map[string]string{
+		"Content-Type":                     "application/json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+	problemHeaders := map[string]string{
+		"Content-Type":                     "application/problem+json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+
+	toSave := []model.Beer{
+		{
+			ID:       1,
+			Name:     "Pilsen",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(2400, 0),
+			Currency: "COP",
+		},
+		{
+			ID:       2,
+			Name:     "Poker",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(2000, 0),
+			Currency: "COP",
+		},
+		{
+			ID:       3,
+			Name:     "Corona",
+			Brewery:  "Modelo",
+			Country:  "Mexico",
+			Price:    money.NewDecimal(200, 0),
+			Currency: "MXN",
+		},
+	}
+	type mocks struct {
+		beersRepository *beersRepositoryMock
+	}
+
+	type fields struct {
+		logger *logrus.Logger
+	}
+
+	type args struct {
+		ctx context.Context
+		req events.APIGatewayProxyRequest
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		mocks   mocks
+		mocker  func(m mocks)
+		args    args
+		want    events.APIGatewayProxyResponse
+		wantErr bool
+	}{
+		{
+			name: "should_return_error_because_body_is_not_a_list",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Body: `{"id":1}`,
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/body_must_be_a_json_list","title":"Bad Request","status":400,"detail":"body_must_be_a_json_list"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_batch_is_empty",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Body: `[]`,
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/batch_can_not_be_empty","title":"Bad Request","status":400,"detail":"batch_can_not_be_empty"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_batch_is_too_big",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Body: "[" + strings.Repeat("{},", maxBatchItems) + "{}]",
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/batch_exceeds_max_items","title":"Bad Request","status":400,"detail":"batch_exceeds_max_items"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_report_beers_without_id_as_invalid",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Body: `[{"name":"Pilsen","brewery":"Bavaria","country":"Colombia","price":2400,"currency":"COP"}]`,
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       `{"created":0,"conflict":0,"invalid":1,"failed":0,"items":[{"index":0,"status":"invalid","message":"beerID_is_required"}]}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_report_every_beer_as_failed_when_saving_fails",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("SaveBatch", toSave).Return([]int(nil), []int{1, 2, 3}, errors.New("error")).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Body: string(batchRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       string(allFailedReportResponse),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_a_report_by_item",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("SaveBatch", toSave).Return([]int{2}, []int(nil), nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Body: string(batchRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       string(reportResponse),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_report_beers_left_unprocessed",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("SaveBatch", toSave).Return([]int{2}, []int{3}, repository.ErrBatchIncomplete).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Body: string(batchRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       string(failedReportResponse),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocker(tt.mocks)
+			h := NewHandler(tt.mocks.beersRepository, tt.fields.logger)
+			got, err := h.Handler(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Handler() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Handler() got = %v, want %v", got, tt.want)
+			}
+
+			tt.mocks.beersRepository.AssertExpectations(t)
+		})
+	}
+}
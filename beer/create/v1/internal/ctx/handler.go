@@ -2,25 +2,33 @@ package ctx
 
 import (
 	"context"
-	_ "embed"
 	"encoding/json"
-	"errors"
 	"github.com/chandy20/prueba-smartjobandina/beer/lib"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
 	"github.com/sirupsen/logrus"
-	"github.com/xeipuuv/gojsonschema"
 )
 
-//errBeerAlreadyCreated custom error to represent that a beer is already created
-var errBeerAlreadyCreated = errors.New("error_beer_already_created")
+//maxIDAttempts times a server ID is allocated again when a client already created a beer with it
+const maxIDAttempts = 3
+
+//errBodyIsNotABeer custom error to represent a body that can not be read as a beer
+var errBodyIsNotABeer = errors.Validation("body_is_not_a_beer")
+
+//errIDsExhausted custom error to represent that every ID allocated was already taken, the client did
+//not choose them so it is a server fault
+var errIDsExhausted = errors.Internal(errors.New("allocated beer IDs were all taken"))
 
 //beerRepositoryInterface contract for beer repository
 type beerRepositoryInterface interface {
-	Find(int) (model.Beer, error)
-	Save(model.Beer) error
+	NextID(context.Context) (int, error)
+	Save(context.Context, model.Beer) error
 }
 
 //Handler main struct for lambda
@@ -29,15 +37,13 @@ type Handler struct {
 	logger          *logrus.Logger
 }
 
-//go:embed json_files/validation.json
-var validationSchema []byte
-
-//Handler main function for lambda
+//Handler main function for lambda, when the body has no id the beer gets one from the repository.
+//The version and deletion time are kept by the repository so the ones in the body are ignored
 func (h *Handler) Handler(
-	_ context.Context,
+	ctx context.Context,
 	req events.APIGatewayProxyRequest,
 ) (events.APIGatewayProxyResponse, error) {
-	logger := h.logger.WithField("request_body", req.Body)
+	logger := lib.Logger(ctx, h.logger).WithField("request_body", req.Body)
 	logger.Info("Beginning of execution of lambda")
 
 	var beer model.Beer
@@ -46,39 +52,56 @@ func (h *Handler) Handler(
 		logger.WithFields(logrus.Fields{
 			"message": req.Body,
 		}).WithError(err).Error("error while unmarshalling")
-		return lib.ResponseError(http.StatusBadRequest, err), nil
+		return lib.ResponseError(req.Path, errBodyIsNotABeer), nil
 	}
+	beer.Version = 0
+	beer.DeletedAt = nil
 
-	dataToValidate := gojsonschema.NewGoLoader(beer)
-	result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(string(validationSchema)), dataToValidate)
+	err = lib.ValidateBeer(&beer)
 	if err != nil {
-		logger.WithError(err).Error("error while validating json schema")
-		return lib.ResponseError(http.StatusBadRequest, err), nil
+		logger.WithError(err).Error("validation errors found")
+		return lib.ResponseError(req.Path, err), nil
 	}
 
-	if !result.Valid() {
-		logger.WithField("errors", result.Errors()).Error("validation errors found")
-		return lib.ResponseError(http.StatusBadRequest, errors.New(result.Errors()[0].String())), nil
+	if beer.ID == 0 {
+		beer.ID, err = h.saveWithNextID(ctx, logger, beer)
+	} else {
+		err = h.beersRepository.Save(ctx, beer)
 	}
-
-	beerGot, err := h.beersRepository.Find(beer.ID)
 	if err != nil {
-		logger.WithError(err).Error("error finding beer")
-		return lib.ResponseError(http.StatusInternalServerError, err), nil
-	}
-
-	if beerGot.ID > 0 {
-		logger.WithField("beer", beerGot).Error("beer already created")
-		return lib.ResponseError(http.StatusConflict, errBeerAlreadyCreated), nil
+		logger.WithError(err).WithField("beer", beer).Error("error saving beer")
+		return lib.ResponseError(req.Path, err), nil
 	}
 
-	err = h.beersRepository.Save(beer)
+	beer.Version = 1
+	response, err := json.Marshal(beer)
 	if err != nil {
-		logger.WithField("beer", beer).Error("error saving  beer")
-		return lib.ResponseError(http.StatusInternalServerError, err), nil
+		return lib.ResponseError(req.Path, err), nil
 	}
 
-	return lib.EmptyResponse(http.StatusCreated), nil
+	resp := lib.JSONResponse(http.StatusCreated, response)
+	resp.Headers["Location"] = strings.TrimSuffix(req.Path, "/") + "/" + strconv.Itoa(beer.ID)
+	resp.Headers["ETag"] = lib.ETag(beer.Version)
+	return resp, nil
+}
+
+//saveWithNextID saves the beer with a server allocated ID, an ID already taken by a client is skipped
+func (h *Handler) saveWithNextID(ctx context.Context, logger *logrus.Entry, beer model.Beer) (int, error) {
+	for attempt := 0; attempt < maxIDAttempts; attempt++ {
+		var err error
+		beer.ID, err = h.beersRepository.NextID(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		err = h.beersRepository.Save(ctx, beer)
+		if !errors.Is(err, repository.ErrAlreadyExists) {
+			return beer.ID, err
+		}
+		logger.WithField("beerID", beer.ID).Info("allocated beer ID is already taken")
+	}
+	logger.WithField("attempts", maxIDAttempts).Error("every allocated beer ID was already taken")
+	return 0, errIDsExhausted
 }
 
 //NewHandler construct for Handler
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
 	"net/http"
 	"reflect"
 	"testing"
@@ -20,12 +21,12 @@ type beerRepositoryMock struct {
 	mock.Mock
 }
 
-func (b *beerRepositoryMock) Find(id int) (model.Beer, error) {
-	args := b.Called(id)
-	return args.Get(0).(model.Beer), args.Error(1)
+func (b *beerRepositoryMock) NextID(_ context.Context) (int, error) {
+	args := b.Called()
+	return args.Int(0), args.Error(1)
 }
 
-func (b *beerRepositoryMock) Save(beer model.Beer) error {
+func (b *beerRepositoryMock) Save(_ context.Context, beer model.Beer) error {
 	return b.Called(beer).Error(0)
 }
 
@@ -35,17 +36,47 @@ var successMessage []byte
 //go:embed golden_files/error_message.json
 var errorMessage []byte
 
+//go:embed golden_files/invalidFieldsResponse.json
+var invalidFieldsResponse []byte
+
+//go:embed golden_files/createdResponse.json
+var createdResponse []byte
+
 func TestHandler_Handler(t *testing.T) {
 	headers := map[string]string{
 		"Content-Type":                     "application/json",
 		"Access-Control-Allow-Origin":      "*",
 		"Access-Control-Allow-Credentials": "true",
 	}
+	problemHeaders := map[string]string{
+		"Content-Type":                     "application/problem+json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
 	var beer model.Beer
 	err := json.Unmarshal(successMessage, &beer)
 	if err != nil {
 		t.Error(err)
 	}
+	withoutID := beer
+	withoutID.ID = 0
+	withoutIDBody, err := json.Marshal(withoutID)
+	if err != nil {
+		t.Error(err)
+	}
+	takenID := beer
+	takenID.ID = 2
+	created := events.APIGatewayProxyResponse{
+		StatusCode: http.StatusCreated,
+		Headers: map[string]string{
+			"Content-Type":                     "application/json",
+			"Access-Control-Allow-Origin":      "*",
+			"Access-Control-Allow-Credentials": "true",
+			"Location":                         "/v1/1",
+			"ETag":                             `"1"`,
+		},
+		Body: string(createdResponse),
+	}
 
 	type mocks struct {
 		beersRepository *beerRepositoryMock
@@ -85,13 +116,13 @@ func TestHandler_Handler(t *testing.T) {
 			mocker: func(m mocks) {},
 			want: events.APIGatewayProxyResponse{
 				StatusCode: http.StatusBadRequest,
-				Headers:    headers,
-				Body:       `{"message":"json: cannot unmarshal string into Go struct field Beer.id of type int"}`,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/body_is_not_a_beer","title":"Bad Request","status":400,"detail":"body_is_not_a_beer"}`,
 			},
 			wantErr: false,
 		},
 		{
-			name: "should_return_error_because_beer_is_already_created",
+			name: "should_return_error_because_price_exceeds_currency_precision",
 			fields: fields{
 				logger: logrus.New(),
 			},
@@ -99,24 +130,91 @@ func TestHandler_Handler(t *testing.T) {
 				ctx: context.Background(),
 				req: events.APIGatewayProxyRequest{
 					Headers: headers,
-					Body:    string(successMessage),
+					Body:    `{"id":1,"name":"Pilsen","brewery":"Babaria","country":"Colombia","price":2300.555,"currency":"COP"}`,
 				},
 			},
 			mocks: mocks{
 				beersRepository: &beerRepositoryMock{},
 			},
-			mocker: func(m mocks) {
-				m.beersRepository.On("Find", beer.ID).Return(beer, nil).Once()
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beer_is_not_valid","title":"Bad Request","status":400,"detail":"beer_is_not_valid","errors":[{"pointer":"/price","code":"price_exceeds_currency_precision"}]}`,
 			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_currency_is_not_supported",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					Body:    `{"id":1,"name":"Pilsen","brewery":"Babaria","country":"Colombia","price":2300,"currency":"ABC"}`,
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
 			want: events.APIGatewayProxyResponse{
-				StatusCode: http.StatusConflict,
-				Headers:    headers,
-				Body:       `{"message":"error_beer_already_created"}`,
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beer_is_not_valid","title":"Bad Request","status":400,"detail":"beer_is_not_valid","errors":[{"pointer":"/currency","code":"currency_not_supported"}]}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_every_invalid_field",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Path:    "/v1",
+					Headers: headers,
+					Body:    `{"name":"P","price":0,"currency":"COP"}`,
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       string(invalidFieldsResponse),
 			},
 			wantErr: false,
 		},
 		{
-			name: "should_return_finding_beer",
+			name: "should_save_beer_with_normalized_currency",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Path:    "/v1",
+					Headers: headers,
+					Body:    `{"id":1,"name":"Pilsen","brewery":"Babaria","country":"Colombia","price":2300,"currency":"cop"}`,
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Save", beer).Return(nil).Once()
+			},
+			want:    created,
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_beer_is_already_created",
 			fields: fields{
 				logger: logrus.New(),
 			},
@@ -131,12 +229,12 @@ func TestHandler_Handler(t *testing.T) {
 				beersRepository: &beerRepositoryMock{},
 			},
 			mocker: func(m mocks) {
-				m.beersRepository.On("Find", beer.ID).Return(model.Beer{}, errors.New("error")).Once()
+				m.beersRepository.On("Save", beer).Return(repository.ErrAlreadyExists).Once()
 			},
 			want: events.APIGatewayProxyResponse{
-				StatusCode: http.StatusInternalServerError,
-				Headers:    headers,
-				Body:       `{"message":"error"}`,
+				StatusCode: http.StatusConflict,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/error_beer_already_created","title":"Conflict","status":409,"detail":"error_beer_already_created"}`,
 			},
 			wantErr: false,
 		},
@@ -156,13 +254,12 @@ func TestHandler_Handler(t *testing.T) {
 				beersRepository: &beerRepositoryMock{},
 			},
 			mocker: func(m mocks) {
-				m.beersRepository.On("Find", beer.ID).Return(model.Beer{}, nil).Once()
 				m.beersRepository.On("Save", beer).Return(errors.New("error")).Once()
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode: http.StatusInternalServerError,
-				Headers:    headers,
-				Body:       `{"message":"error"}`,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
 			},
 			wantErr: false,
 		},
@@ -174,6 +271,7 @@ func TestHandler_Handler(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: events.APIGatewayProxyRequest{
+					Path:    "/v1",
 					Headers: headers,
 					Body:    string(successMessage),
 				},
@@ -182,15 +280,106 @@ func TestHandler_Handler(t *testing.T) {
 				beersRepository: &beerRepositoryMock{},
 			},
 			mocker: func(m mocks) {
-				m.beersRepository.On("Find", beer.ID).Return(model.Beer{}, nil).Once()
 				m.beersRepository.On("Save", beer).Return(nil).Once()
 			},
+			want:    created,
+			wantErr: false,
+		},
+		{
+			name: "should_ignore_version_and_deletion_time_of_body",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Path:    "/v1/",
+					Headers: headers,
+					Body:    `{"id":1,"name":"Pilsen","brewery":"Babaria","country":"Colombia","price":2300,"currency":"COP","version":7,"deleted_at":"2021-01-02T03:04:05Z"}`,
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Save", beer).Return(nil).Once()
+			},
+			want:    created,
+			wantErr: false,
+		},
+		{
+			name: "should_create_beer_with_server_id",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Path:    "/v1",
+					Headers: headers,
+					Body:    string(withoutIDBody),
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("NextID").Return(2, nil).Once()
+				m.beersRepository.On("Save", takenID).Return(repository.ErrAlreadyExists).Once()
+				m.beersRepository.On("NextID").Return(1, nil).Once()
+				m.beersRepository.On("Save", beer).Return(nil).Once()
+			},
+			want:    created,
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_every_allocated_id_is_taken",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					Body:    string(withoutIDBody),
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("NextID").Return(2, nil).Times(maxIDAttempts)
+				m.beersRepository.On("Save", takenID).Return(repository.ErrAlreadyExists).Times(maxIDAttempts)
+			},
 			want: events.APIGatewayProxyResponse{
-				StatusCode: http.StatusCreated,
-				Headers: map[string]string{
-					"Content-Type": "text/plain",
+				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_allocating_beer_id",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					Body:    string(withoutIDBody),
 				},
-				Body: "",
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("NextID").Return(0, errors.New("error")).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
 			},
 			wantErr: false,
 		},
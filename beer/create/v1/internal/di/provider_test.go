@@ -1,6 +1,7 @@
 package di
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/chandy20/prueba-smartjobandina/beer/create/v1/internal/ctx"
 	"github.com/chandy20/prueba-smartjobandina/beer/repository"
 	"os"
@@ -10,20 +11,28 @@ import (
 
 func Test_providerAwsSession(t *testing.T) {
 	tests := []struct {
-		name    string
-		wantErr bool
+		name         string
+		endpoint     string
+		wantEndpoint string
 	}{
 		{
-			name:    "should_build_aws_config_correctly",
-			wantErr: false,
+			name: "should_build_aws_config_correctly",
+		},
+		{
+			name:         "should_point_to_dynamodb_local",
+			endpoint:     "http://localhost:8000",
+			wantEndpoint: "http://localhost:8000",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := providerAWSConfig()
-			if tt.wantErr && err == nil {
-				t.Errorf("awsConfigProvider() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			t.Setenv("DYNAMODB_ENDPOINT", tt.endpoint)
+			got := ""
+			for _, config := range providerAWSConfig() {
+				got = aws.StringValue(config.Endpoint)
+			}
+			if got != tt.wantEndpoint {
+				t.Errorf("providerAWSConfig() endpoint = %v, want %v", got, tt.wantEndpoint)
 			}
 		})
 	}
@@ -0,0 +1,143 @@
+package ctx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	"github.com/sirupsen/logrus"
+)
+
+//errMethodNotAllowed custom error to represent a method other than DELETE or POST
+var errMethodNotAllowed = errors.MethodNotAllowed("method_not_allowed")
+
+//allowedMethods value of the Allow header of a method that is not allowed
+const allowedMethods = "DELETE, POST"
+
+//beerRepositoryInterface contract for beer repository
+type beerRepositoryInterface interface {
+	Find(context.Context, int) (model.Beer, error)
+	SoftDelete(context.Context, int) (model.Beer, error)
+	Restore(context.Context, int) (model.Beer, error)
+}
+
+//Handler main struct for lambda
+type Handler struct {
+	beersRepository beerRepositoryInterface
+	logger          *logrus.Logger
+}
+
+//Handler main function for lambda, DELETE soft deletes the beer and POST restores it
+func (h *Handler) Handler(
+	ctx context.Context,
+	req events.APIGatewayProxyRequest,
+) (events.APIGatewayProxyResponse, error) {
+	logger := lib.Logger(ctx, h.logger).WithField("method", req.HTTPMethod).WithField("path", req.Path)
+	logger.Info("Beginning of execution of lambda")
+
+	var action func(context.Context, events.APIGatewayProxyRequest, *logrus.Entry, model.Beer) (events.APIGatewayProxyResponse, error)
+	switch req.HTTPMethod {
+	case http.MethodDelete:
+		action = h.softDelete
+	case http.MethodPost:
+		action = h.restore
+	default:
+		resp := lib.ResponseError(req.Path, errMethodNotAllowed)
+		resp.Headers["Allow"] = allowedMethods
+		return resp, nil
+	}
+
+	IDString := req.PathParameters["beerID"]
+	if strings.TrimSpace(IDString) == "" {
+		return lib.ResponseError(req.Path, errors.Validation("beerID_can_not_be_empty")), nil
+	}
+
+	ID, err := strconv.Atoi(IDString)
+	if err != nil {
+		return lib.ResponseError(req.Path, errors.Validation("beerID_is_not_a_number")), nil
+	}
+
+	beer, err := h.beersRepository.Find(ctx, ID)
+	if err != nil {
+		logger.WithError(err).Error("error finding beer")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	if beer.ID == 0 {
+		return lib.ResponseError(req.Path, repository.ErrBeerNotFound), nil
+	}
+
+	return action(ctx, req, logger, beer)
+}
+
+//softDelete takes the beer out of the listings, deleting a deleted beer does not change it
+func (h *Handler) softDelete(
+	ctx context.Context,
+	req events.APIGatewayProxyRequest,
+	logger *logrus.Entry,
+	beer model.Beer,
+) (events.APIGatewayProxyResponse, error) {
+	if !beer.Active() {
+		return lib.EmptyResponse(http.StatusNoContent), nil
+	}
+
+	_, err := h.beersRepository.SoftDelete(ctx, beer.ID)
+	if errors.Is(err, repository.ErrBeerNotFound) {
+		return lib.ResponseError(req.Path, err), nil
+	}
+	if err != nil {
+		logger.WithError(err).Error("error deleting beer")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	return lib.EmptyResponse(http.StatusNoContent), nil
+}
+
+//restore lists again a soft deleted beer, restoring an active beer does not change it
+func (h *Handler) restore(
+	ctx context.Context,
+	req events.APIGatewayProxyRequest,
+	logger *logrus.Entry,
+	beer model.Beer,
+) (events.APIGatewayProxyResponse, error) {
+	var err error
+	if !beer.Active() {
+		beer, err = h.beersRepository.Restore(ctx, beer.ID)
+		if errors.Is(err, repository.ErrBeerNotFound) {
+			return lib.ResponseError(req.Path, err), nil
+		}
+		if err != nil {
+			logger.WithError(err).Error("error restoring beer")
+			return lib.ResponseError(req.Path, err), nil
+		}
+	}
+
+	response, err := json.Marshal(beer)
+	if err != nil {
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	resp := lib.JSONResponse(http.StatusOK, response)
+	if beer.Version > 0 {
+		resp.Headers["ETag"] = lib.ETag(beer.Version)
+	}
+	return resp, nil
+}
+
+//NewHandler construct for Handler
+func NewHandler(
+	beersRepository beerRepositoryInterface,
+	logger *logrus.Logger,
+) *Handler {
+	return &Handler{
+		beersRepository: beersRepository,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,442 @@
+package ctx
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+//beersRepositoryMock mock for represent beers repository
+type beersRepositoryMock struct {
+	mock.Mock
+}
+
+func (b *beersRepositoryMock) Find(_ context.Context, ID int) (model.Beer, error) {
+	args := b.Called(ID)
+	return args.Get(0).(model.Beer), args.Error(1)
+}
+
+func (b *beersRepositoryMock) SoftDelete(_ context.Context, ID int) (model.Beer, error) {
+	args := b.Called(ID)
+	return args.Get(0).(model.Beer), args.Error(1)
+}
+
+func (b *beersRepositoryMock) Restore(_ context.Context, ID int) (model.Beer, error) {
+	args := b.Called(ID)
+	return args.Get(0).(model.Beer), args.Error(1)
+}
+
+//go:embed golden_files/restoredResponse.json
+var restoredResponse []byte
+
+func TestHandler_Handler(t *testing.T) {
+	problemHeaders := map[string]string{
+		"Content-Type":                     "application/problem+json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+	emptyHeaders := map[string]string{
+		"Content-Type": "text/plain",
+	}
+
+	active := model.Beer{
+		ID:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    money.NewDecimal(2400, 0),
+		Currency: "COP",
+		Version:  1,
+	}
+	deletedAt := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+	deleted := active
+	deleted.Version = 2
+	deleted.DeletedAt = &deletedAt
+	restored := active
+	restored.Version = 3
+
+	type mocks struct {
+		beersRepository *beersRepositoryMock
+	}
+
+	type fields struct {
+		logger *logrus.Logger
+	}
+
+	type args struct {
+		ctx context.Context
+		req events.APIGatewayProxyRequest
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		mocks   mocks
+		mocker  func(m mocks)
+		args    args
+		want    events.APIGatewayProxyResponse
+		wantErr bool
+	}{
+		{
+			name: "should_return_error_because_method_is_not_allowed",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodOptions,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusMethodNotAllowed,
+				Headers: map[string]string{
+					"Content-Type":                     "application/problem+json",
+					"Access-Control-Allow-Origin":      "*",
+					"Access-Control-Allow-Credentials": "true",
+					"Allow":                            "DELETE, POST",
+				},
+				Body: `{"type":"/problems/method_not_allowed","title":"Method Not Allowed","status":405,"detail":"method_not_allowed"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_beer_id_is_not_present",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod:     http.MethodDelete,
+					PathParameters: map[string]string{},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beerID_can_not_be_empty","title":"Bad Request","status":400,"detail":"beerID_can_not_be_empty"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_beer_id_is_not_a_number",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodDelete,
+					PathParameters: map[string]string{
+						"beerID": "a",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beerID_is_not_a_number","title":"Bad Request","status":400,"detail":"beerID_is_not_a_number"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_finding_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(model.Beer{}, errors.New("error")).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodDelete,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_beer_id_does_not_exist",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(model.Beer{}, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodDelete,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusNotFound,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beerID_does_not_exist","title":"Not Found","status":404,"detail":"beerID_does_not_exist"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_deleting_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(active, nil).Once()
+				m.beersRepository.On("SoftDelete", 1).Return(model.Beer{}, errors.New("error")).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodDelete,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_delete_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(active, nil).Once()
+				m.beersRepository.On("SoftDelete", 1).Return(deleted, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodDelete,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusNoContent,
+				Headers:    emptyHeaders,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_not_delete_again_a_deleted_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(deleted, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodDelete,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusNoContent,
+				Headers:    emptyHeaders,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_restoring_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(deleted, nil).Once()
+				m.beersRepository.On("Restore", 1).Return(model.Beer{}, errors.New("error")).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPost,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_restore_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(deleted, nil).Once()
+				m.beersRepository.On("Restore", 1).Return(restored, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPost,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers: map[string]string{
+					"Content-Type":                     "application/json",
+					"Access-Control-Allow-Origin":      "*",
+					"Access-Control-Allow-Credentials": "true",
+					"ETag":                             `"3"`,
+				},
+				Body: string(restoredResponse),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_not_restore_an_active_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(restored, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPost,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers: map[string]string{
+					"Content-Type":                     "application/json",
+					"Access-Control-Allow-Origin":      "*",
+					"Access-Control-Allow-Credentials": "true",
+					"ETag":                             `"3"`,
+				},
+				Body: string(restoredResponse),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_not_found_when_beer_disappears",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(active, nil).Once()
+				m.beersRepository.On("SoftDelete", 1).Return(model.Beer{}, repository.ErrBeerNotFound).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodDelete,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusNotFound,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beerID_does_not_exist","title":"Not Found","status":404,"detail":"beerID_does_not_exist"}`,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocker(tt.mocks)
+			h := NewHandler(tt.mocks.beersRepository, tt.fields.logger)
+			got, err := h.Handler(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Handler() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Handler() got = %v, want %v", got, tt.want)
+			}
+
+			tt.mocks.beersRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package exchange
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+//RateStore contract for a store of quotes shared between lambda instances
+type RateStore interface {
+	Get(ctx context.Context, from, to string) (Quote, bool, error)
+	Put(ctx context.Context, quote Quote) error
+}
+
+//Cache converter that keeps quotes in memory and in an optional RateStore,
+//when the wrapped converter fails it serves the last known quote marked as stale
+type Cache struct {
+	converter CurrencyConverter
+	store     RateStore
+	ttl       time.Duration
+	now       func() time.Time
+	mutex     sync.Mutex
+	quotes    map[string]Quote
+}
+
+//Rate returns a fresh cached quote or asks the wrapped converter for a new one, when ctx runs out
+//of time before the converter answers the last known quote is served as stale
+func (c *Cache) Rate(ctx context.Context, from, to string) (Quote, error) {
+	key := pairKey(from, to)
+
+	last, ok := c.memory(key)
+	if ok && c.fresh(last) {
+		return last, nil
+	}
+
+	if c.store != nil {
+		stored, found, err := c.store.Get(ctx, from, to)
+		if err == nil && found {
+			if !ok || stored.FetchedAt.After(last.FetchedAt) {
+				last, ok = stored, true
+				c.remember(key, stored)
+			}
+			if c.fresh(stored) {
+				return stored, nil
+			}
+		}
+	}
+
+	quote, err := c.converter.Rate(ctx, from, to)
+	if err != nil {
+		if ok {
+			last.Stale = true
+			return last, nil
+		}
+		return Quote{}, err
+	}
+
+	quote.FetchedAt = c.now()
+	quote.Stale = false
+	c.remember(key, quote)
+	if c.store != nil {
+		// a failure sharing the quote must not hide a successful conversion
+		_ = c.store.Put(ctx, quote)
+	}
+
+	return quote, nil
+}
+
+//memory returns the quote kept in memory for the pair
+func (c *Cache) memory(key string) (Quote, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	quote, ok := c.quotes[key]
+	return quote, ok
+}
+
+//remember keeps the quote in memory for the pair
+func (c *Cache) remember(key string, quote Quote) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.quotes[key] = quote
+}
+
+//fresh indicates if quote is younger than ttl
+func (c *Cache) fresh(quote Quote) bool {
+	return c.now().Sub(quote.FetchedAt) < c.ttl
+}
+
+//pairKey key used to cache a currency pair
+func pairKey(from, to string) string {
+	return strings.ToUpper(from) + "/" + strings.ToUpper(to)
+}
+
+//NewCache construct for Cache, store can be nil to keep quotes only in memory
+func NewCache(
+	converter CurrencyConverter,
+	store RateStore,
+	ttl time.Duration,
+) *Cache {
+	return &Cache{
+		converter: converter,
+		store:     store,
+		ttl:       ttl,
+		now:       time.Now,
+		quotes:    map[string]Quote{},
+	}
+}
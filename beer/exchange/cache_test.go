@@ -0,0 +1,145 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+//currencyConverterMock mock for represent wrapped converter
+type currencyConverterMock struct {
+	mock.Mock
+}
+
+func (c *currencyConverterMock) Rate(_ context.Context, from, to string) (Quote, error) {
+	args := c.Called(from, to)
+	return args.Get(0).(Quote), args.Error(1)
+}
+
+//rateStoreMock mock for represent shared rate store
+type rateStoreMock struct {
+	mock.Mock
+}
+
+func (r *rateStoreMock) Get(_ context.Context, from, to string) (Quote, bool, error) {
+	args := r.Called(from, to)
+	return args.Get(0).(Quote), args.Bool(1), args.Error(2)
+}
+
+func (r *rateStoreMock) Put(_ context.Context, quote Quote) error {
+	return r.Called(quote).Error(0)
+}
+
+func TestCache_Rate(t *testing.T) {
+	now := time.Date(2022, 1, 17, 12, 0, 0, 0, time.UTC)
+	fresh := Quote{From: "COP", To: "USD", Rate: 0.00025, FetchedAt: now.Add(-time.Minute)}
+	old := Quote{From: "COP", To: "USD", Rate: 0.0002, FetchedAt: now.Add(-2 * time.Hour)}
+	newer := Quote{From: "COP", To: "USD", Rate: 0.0003, FetchedAt: now}
+
+	type mocks struct {
+		converter *currencyConverterMock
+		store     *rateStoreMock
+	}
+	tests := []struct {
+		name      string
+		withStore bool
+		memory    map[string]Quote
+		mocker    func(m mocks)
+		want      Quote
+		wantErr   bool
+	}{
+		{
+			name:   "should_return_fresh_quote_from_memory",
+			memory: map[string]Quote{"COP/USD": fresh},
+			mocker: func(m mocks) {},
+			want:   fresh,
+		},
+		{
+			name:   "should_refresh_expired_quote",
+			memory: map[string]Quote{"COP/USD": old},
+			mocker: func(m mocks) {
+				m.converter.On("Rate", "COP", "USD").Return(Quote{From: "COP", To: "USD", Rate: 0.0003}, nil).Once()
+			},
+			want: newer,
+		},
+		{
+			name:   "should_return_stale_quote_because_converter_fails",
+			memory: map[string]Quote{"COP/USD": old},
+			mocker: func(m mocks) {
+				m.converter.On("Rate", "COP", "USD").Return(Quote{}, errors.New("error")).Once()
+			},
+			want: Quote{From: "COP", To: "USD", Rate: 0.0002, FetchedAt: old.FetchedAt, Stale: true},
+		},
+		{
+			name:   "should_return_error_because_there_is_no_known_quote",
+			memory: map[string]Quote{},
+			mocker: func(m mocks) {
+				m.converter.On("Rate", "COP", "USD").Return(Quote{}, errors.New("error")).Once()
+			},
+			want:    Quote{},
+			wantErr: true,
+		},
+		{
+			name:      "should_return_fresh_quote_from_store",
+			withStore: true,
+			memory:    map[string]Quote{},
+			mocker: func(m mocks) {
+				m.store.On("Get", "COP", "USD").Return(fresh, true, nil).Once()
+			},
+			want: fresh,
+		},
+		{
+			name:      "should_share_new_quote_in_store",
+			withStore: true,
+			memory:    map[string]Quote{},
+			mocker: func(m mocks) {
+				m.store.On("Get", "COP", "USD").Return(Quote{}, false, nil).Once()
+				m.converter.On("Rate", "COP", "USD").Return(Quote{From: "COP", To: "USD", Rate: 0.0003}, nil).Once()
+				m.store.On("Put", newer).Return(nil).Once()
+			},
+			want: newer,
+		},
+		{
+			name:      "should_return_stale_quote_from_store_because_converter_fails",
+			withStore: true,
+			memory:    map[string]Quote{},
+			mocker: func(m mocks) {
+				m.store.On("Get", "COP", "USD").Return(old, true, nil).Once()
+				m.converter.On("Rate", "COP", "USD").Return(Quote{}, errors.New("error")).Once()
+			},
+			want: Quote{From: "COP", To: "USD", Rate: 0.0002, FetchedAt: old.FetchedAt, Stale: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks{
+				converter: &currencyConverterMock{},
+				store:     &rateStoreMock{},
+			}
+			tt.mocker(m)
+
+			var store RateStore
+			if tt.withStore {
+				store = m.store
+			}
+			c := NewCache(m.converter, store, time.Hour)
+			c.now = func() time.Time { return now }
+			c.quotes = tt.memory
+
+			got, err := c.Rate(context.Background(), "COP", "USD")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rate() got = %v, want %v", got, tt.want)
+			}
+			m.converter.AssertExpectations(t)
+			m.store.AssertExpectations(t)
+		})
+	}
+}
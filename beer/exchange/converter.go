@@ -0,0 +1,75 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+)
+
+const (
+	//ProviderCurrencyLayer name of currencylayer provider
+	ProviderCurrencyLayer = "currencylayer"
+	//ProviderECB name of european central bank provider, also used by exchangerate.host
+	ProviderECB = "ecb"
+	//ProviderStatic name of provider that reads rates from a file
+	ProviderStatic = "static"
+)
+
+//ErrRateNotFound custom error to represent that provider does not know the currency pair
+var ErrRateNotFound = errors.Validation("exchange_rate_not_found")
+
+//httpClientInterface contract for http client
+type httpClientInterface interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+//CurrencyConverter contract for exchange rate providers, calls to remote providers are cancelled with ctx
+type CurrencyConverter interface {
+	Rate(ctx context.Context, from, to string) (Quote, error)
+}
+
+//Quote exchange rate to convert one unit of From into To
+type Quote struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Rate      float64   `json:"rate"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Stale     bool      `json:"stale"`
+}
+
+//Config values to choose and build a CurrencyConverter
+type Config struct {
+	Provider   string
+	AccessKey  string
+	RatesFile  string
+	HTTPClient httpClientInterface
+}
+
+//New builds the CurrencyConverter selected in config, currencylayer when provider is empty
+func New(config Config) (CurrencyConverter, error) {
+	switch strings.ToLower(config.Provider) {
+	case "", ProviderCurrencyLayer:
+		if config.AccessKey == "" {
+			return nil, errors.New("access key is required for currencylayer provider")
+		}
+		return NewCurrencyLayer(config.HTTPClient, config.AccessKey), nil
+	case ProviderECB:
+		return NewECB(config.HTTPClient), nil
+	case ProviderStatic:
+		return NewStaticRatesFromFile(config.RatesFile)
+	default:
+		return nil, fmt.Errorf("currency provider %v is not supported", config.Provider)
+	}
+}
+
+//identity returns a quote with rate 1 when both currencies are the same
+func identity(from, to string) (Quote, bool) {
+	if !strings.EqualFold(from, to) {
+		return Quote{}, false
+	}
+	return Quote{From: from, To: to, Rate: 1}, true
+}
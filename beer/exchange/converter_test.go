@@ -0,0 +1,90 @@
+package exchange
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+//httpClientMock mock for represent http client
+type httpClientMock struct {
+	mock.Mock
+}
+
+func (h *httpClientMock) Do(req *http.Request) (*http.Response, error) {
+	args := h.Called(req)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+//newHTTPResponse build a http response with the given status and body
+func newHTTPResponse(statusCode int, body []byte) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		want    CurrencyConverter
+		wantErr bool
+	}{
+		{
+			name:    "should_fail_because_currencylayer_needs_access_key",
+			config:  Config{},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "should_build_currencylayer_by_default",
+			config:  Config{AccessKey: "some-key"},
+			want:    &CurrencyLayer{},
+			wantErr: false,
+		},
+		{
+			name:    "should_build_ecb",
+			config:  Config{Provider: "ECB"},
+			want:    &ECB{},
+			wantErr: false,
+		},
+		{
+			name:    "should_build_static_rates",
+			config:  Config{Provider: ProviderStatic, RatesFile: "json_files/rates.json"},
+			want:    &StaticRates{},
+			wantErr: false,
+		},
+		{
+			name:    "should_fail_because_rates_file_does_not_exist",
+			config:  Config{Provider: ProviderStatic, RatesFile: "json_files/missing.json"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "should_fail_because_provider_is_not_supported",
+			config:  Config{Provider: "bank"},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if reflect.TypeOf(got) != reflect.TypeOf(tt.want) {
+				t.Errorf("New() got = %T, want %T", got, tt.want)
+			}
+		})
+	}
+}
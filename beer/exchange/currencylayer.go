@@ -0,0 +1,85 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+//currencyLayerURL base url for currencylayer api
+const currencyLayerURL = "https://api.currencylayer.com"
+
+//currencyLayerResponse body returned by currencylayer on convert endpoint
+type currencyLayerResponse struct {
+	Success bool `json:"success"`
+	Error   struct {
+		Code int    `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+	Info struct {
+		Quote float64 `json:"quote"`
+	} `json:"info"`
+}
+
+//CurrencyLayer converter backed by currencylayer convert endpoint
+type CurrencyLayer struct {
+	httpClient httpClientInterface
+	accessKey  string
+	baseURL    string
+}
+
+//Rate asks currencylayer for the rate of one unit of from in to
+func (c *CurrencyLayer) Rate(ctx context.Context, from, to string) (Quote, error) {
+	if quote, ok := identity(from, to); ok {
+		return quote, nil
+	}
+
+	query := url.Values{}
+	query.Set("access_key", c.accessKey)
+	query.Set("from", from)
+	query.Set("to", to)
+	query.Set("amount", "1")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/convert?"+query.Encode(), nil)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return Quote{}, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	var conversion currencyLayerResponse
+	err = json.Unmarshal(body, &conversion)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	if !conversion.Success {
+		return Quote{}, fmt.Errorf("currencylayer error %v: %v", conversion.Error.Code, conversion.Error.Info)
+	}
+
+	return Quote{From: from, To: to, Rate: conversion.Info.Quote}, nil
+}
+
+//NewCurrencyLayer construct for CurrencyLayer
+func NewCurrencyLayer(
+	httpClient httpClientInterface,
+	accessKey string,
+) *CurrencyLayer {
+	return &CurrencyLayer{
+		httpClient: httpClient,
+		accessKey:  accessKey,
+		baseURL:    currencyLayerURL,
+	}
+}
@@ -0,0 +1,87 @@
+package exchange
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+//go:embed golden_files/currencyLayerResponse.json
+var currencyLayerResponseBody []byte
+
+func TestCurrencyLayer_Rate(t *testing.T) {
+	type args struct {
+		from string
+		to   string
+	}
+	tests := []struct {
+		name    string
+		mocker  func(m *httpClientMock)
+		args    args
+		want    Quote
+		wantErr bool
+	}{
+		{
+			name:    "should_not_call_api_for_same_currency",
+			mocker:  func(m *httpClientMock) {},
+			args:    args{from: "COP", to: "COP"},
+			want:    Quote{From: "COP", To: "COP", Rate: 1},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_request_fails",
+			mocker: func(m *httpClientMock) {
+				m.On("Do", mock.Anything).Return(newHTTPResponse(0, nil), errors.New("error")).Once()
+			},
+			args:    args{from: "COP", to: "USD"},
+			want:    Quote{},
+			wantErr: true,
+		},
+		{
+			name: "should_return_error_because_api_was_not_successful",
+			mocker: func(m *httpClientMock) {
+				m.On("Do", mock.Anything).Return(
+					newHTTPResponse(http.StatusOK, []byte(`{"success":false,"error":{"code":101,"info":"invalid key"}}`)), nil).Once()
+			},
+			args:    args{from: "COP", to: "USD"},
+			want:    Quote{},
+			wantErr: true,
+		},
+		{
+			name: "should_return_quote",
+			mocker: func(m *httpClientMock) {
+				m.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+					query := req.URL.Query()
+					return req.URL.Path == "/convert" &&
+						query.Get("access_key") == "some-key" &&
+						query.Get("from") == "COP" &&
+						query.Get("to") == "USD"
+				})).Return(newHTTPResponse(http.StatusOK, currencyLayerResponseBody), nil).Once()
+			},
+			args:    args{from: "COP", to: "USD"},
+			want:    Quote{From: "COP", To: "USD", Rate: 0.00025},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &httpClientMock{}
+			tt.mocker(httpClient)
+			c := NewCurrencyLayer(httpClient, "some-key")
+			got, err := c.Rate(context.Background(), tt.args.from, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rate() got = %v, want %v", got, tt.want)
+			}
+			httpClient.AssertExpectations(t)
+		})
+	}
+}
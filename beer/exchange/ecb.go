@@ -0,0 +1,72 @@
+package exchange
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//ecbURL daily reference rates published by european central bank, exchangerate.host serves the same data
+const ecbURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+//ecbBase currency used by ecb as base for every rate
+const ecbBase = "EUR"
+
+//ecbEnvelope xml document returned by ecb
+type ecbEnvelope struct {
+	Cubes []struct {
+		Currency string  `xml:"currency,attr"`
+		Rate     float64 `xml:"rate,attr"`
+	} `xml:"Cube>Cube>Cube"`
+}
+
+//ECB converter backed by european central bank reference rates
+type ECB struct {
+	httpClient httpClientInterface
+	url        string
+}
+
+//Rate calculates the cross rate of one unit of from in to using EUR as base
+func (e *ECB) Rate(ctx context.Context, from, to string) (Quote, error) {
+	if quote, ok := identity(from, to); ok {
+		return quote, nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url, nil)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	response, err := e.httpClient.Do(request)
+	if err != nil {
+		return Quote{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return Quote{}, fmt.Errorf("ecb responded with status %v", response.StatusCode)
+	}
+
+	var envelope ecbEnvelope
+	err = xml.NewDecoder(response.Body).Decode(&envelope)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	rates := map[string]float64{ecbBase: 1}
+	for _, cube := range envelope.Cubes {
+		rates[strings.ToUpper(cube.Currency)] = cube.Rate
+	}
+
+	return crossRate(rates, from, to)
+}
+
+//NewECB construct for ECB
+func NewECB(httpClient httpClientInterface) *ECB {
+	return &ECB{
+		httpClient: httpClient,
+		url:        ecbURL,
+	}
+}
@@ -0,0 +1,81 @@
+package exchange
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+//go:embed golden_files/eurofxref-daily.xml
+var ecbResponseBody []byte
+
+func TestECB_Rate(t *testing.T) {
+	type args struct {
+		from string
+		to   string
+	}
+	tests := []struct {
+		name    string
+		mocker  func(m *httpClientMock)
+		args    args
+		want    Quote
+		wantErr bool
+	}{
+		{
+			name: "should_return_error_because_ecb_is_unavailable",
+			mocker: func(m *httpClientMock) {
+				m.On("Do", mock.Anything).Return(newHTTPResponse(http.StatusServiceUnavailable, nil), nil).Once()
+			},
+			args:    args{from: "USD", to: "MXN"},
+			want:    Quote{},
+			wantErr: true,
+		},
+		{
+			name: "should_return_error_because_currency_is_not_published",
+			mocker: func(m *httpClientMock) {
+				m.On("Do", mock.Anything).Return(newHTTPResponse(http.StatusOK, ecbResponseBody), nil).Once()
+			},
+			args:    args{from: "COP", to: "USD"},
+			want:    Quote{},
+			wantErr: true,
+		},
+		{
+			name: "should_return_rate_from_euro",
+			mocker: func(m *httpClientMock) {
+				m.On("Do", mock.Anything).Return(newHTTPResponse(http.StatusOK, ecbResponseBody), nil).Once()
+			},
+			args:    args{from: "EUR", to: "USD"},
+			want:    Quote{From: "EUR", To: "USD", Rate: 1.25},
+			wantErr: false,
+		},
+		{
+			name: "should_return_cross_rate",
+			mocker: func(m *httpClientMock) {
+				m.On("Do", mock.Anything).Return(newHTTPResponse(http.StatusOK, ecbResponseBody), nil).Once()
+			},
+			args:    args{from: "USD", to: "MXN"},
+			want:    Quote{From: "USD", To: "MXN", Rate: 20},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &httpClientMock{}
+			tt.mocker(httpClient)
+			e := NewECB(httpClient)
+			got, err := e.Rate(context.Background(), tt.args.from, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rate() got = %v, want %v", got, tt.want)
+			}
+			httpClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+)
+
+//StaticRates converter backed by a fixed rate table, every rate is the value of one unit of base
+type StaticRates struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+//Rate calculates the cross rate of one unit of from in to using the table base
+func (s *StaticRates) Rate(_ context.Context, from, to string) (Quote, error) {
+	if quote, ok := identity(from, to); ok {
+		return quote, nil
+	}
+
+	rates := map[string]float64{strings.ToUpper(s.Base): 1}
+	for currency, rate := range s.Rates {
+		rates[strings.ToUpper(currency)] = rate
+	}
+
+	return crossRate(rates, from, to)
+}
+
+//NewStaticRatesFromFile reads a json rate table like {"base":"USD","rates":{"COP":3900}}
+func NewStaticRatesFromFile(path string) (*StaticRates, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates StaticRates
+	err = json.Unmarshal(content, &rates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rates, nil
+}
+
+//crossRate calculates the rate between two currencies quoted against the same base
+func crossRate(rates map[string]float64, from, to string) (Quote, error) {
+	fromRate, ok := rates[strings.ToUpper(from)]
+	if !ok || fromRate == 0 {
+		return Quote{}, ErrRateNotFound
+	}
+
+	toRate, ok := rates[strings.ToUpper(to)]
+	if !ok {
+		return Quote{}, ErrRateNotFound
+	}
+
+	return Quote{From: from, To: to, Rate: toRate / fromRate}, nil
+}
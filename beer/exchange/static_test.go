@@ -0,0 +1,55 @@
+package exchange
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestStaticRates_Rate(t *testing.T) {
+	type args struct {
+		from string
+		to   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Quote
+		wantErr bool
+	}{
+		{
+			name:    "should_return_error_because_currency_is_unknown",
+			args:    args{from: "USD", to: "JPY"},
+			want:    Quote{},
+			wantErr: true,
+		},
+		{
+			name:    "should_return_rate_from_base",
+			args:    args{from: "USD", to: "COP"},
+			want:    Quote{From: "USD", To: "COP", Rate: 4000},
+			wantErr: false,
+		},
+		{
+			name:    "should_return_cross_rate",
+			args:    args{from: "mxn", to: "cop"},
+			want:    Quote{From: "mxn", To: "cop", Rate: 200},
+			wantErr: false,
+		},
+	}
+	rates, err := NewStaticRatesFromFile("json_files/rates.json")
+	if err != nil {
+		t.Fatalf("error reading rates %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rates.Rate(context.Background(), tt.args.from, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package exchange
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+//DynamoRateStore RateStore backed by a dynamodb table with "pair" as hash key
+type DynamoRateStore struct {
+	client     *dynamodb.DynamoDB
+	tableRates string
+}
+
+//Get reads the last quote saved for the pair
+func (d *DynamoRateStore) Get(ctx context.Context, from, to string) (Quote, bool, error) {
+	out, err := d.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableRates),
+		Key: map[string]*dynamodb.AttributeValue{
+			"pair": {
+				S: aws.String(pairKey(from, to)),
+			},
+		},
+	})
+	if err != nil {
+		return Quote{}, false, err
+	}
+
+	return quoteOf(from, to, out.Item)
+}
+
+//quoteOf reads the quote of an item, items without a rate or a fetch time, like partial writes, are
+//taken as missing so the quote is fetched again
+func quoteOf(from, to string, item map[string]*dynamodb.AttributeValue) (Quote, bool, error) {
+	rateValue, fetchedAtValue := item["rate"], item["fetched_at"]
+	if rateValue == nil || rateValue.N == nil || fetchedAtValue == nil || fetchedAtValue.N == nil {
+		return Quote{}, false, nil
+	}
+
+	rate, err := strconv.ParseFloat(*rateValue.N, 64)
+	if err != nil {
+		return Quote{}, false, err
+	}
+
+	fetchedAt, err := strconv.ParseInt(*fetchedAtValue.N, 10, 64)
+	if err != nil {
+		return Quote{}, false, err
+	}
+
+	return Quote{
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		FetchedAt: time.Unix(fetchedAt, 0),
+	}, true, nil
+}
+
+//Put saves the quote as the last one known for the pair
+func (d *DynamoRateStore) Put(ctx context.Context, quote Quote) error {
+	_, err := d.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.tableRates),
+		Item: map[string]*dynamodb.AttributeValue{
+			"pair": {
+				S: aws.String(pairKey(quote.From, quote.To)),
+			},
+			"from": {
+				S: aws.String(strings.ToUpper(quote.From)),
+			},
+			"to": {
+				S: aws.String(strings.ToUpper(quote.To)),
+			},
+			"rate": {
+				N: aws.String(strconv.FormatFloat(quote.Rate, 'f', -1, 64)),
+			},
+			"fetched_at": {
+				N: aws.String(strconv.FormatInt(quote.FetchedAt.Unix(), 10)),
+			},
+		},
+	})
+
+	return err
+}
+
+//NewDynamoRateStore construct for DynamoRateStore
+func NewDynamoRateStore(
+	client *dynamodb.DynamoDB,
+	tableRates string,
+) *DynamoRateStore {
+	return &DynamoRateStore{
+		client:     client,
+		tableRates: tableRates,
+	}
+}
@@ -0,0 +1,85 @@
+package exchange
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+func Test_quoteOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		item      map[string]*dynamodb.AttributeValue
+		want      Quote
+		wantFound bool
+		wantErr   bool
+	}{
+		{
+			name:      "should_not_find_a_missing_item",
+			item:      nil,
+			want:      Quote{},
+			wantFound: false,
+			wantErr:   false,
+		},
+		{
+			name: "should_not_find_an_item_without_fetched_at",
+			item: map[string]*dynamodb.AttributeValue{
+				"pair": {S: aws.String("USD:COP")},
+				"rate": {N: aws.String("4000")},
+			},
+			want:      Quote{},
+			wantFound: false,
+			wantErr:   false,
+		},
+		{
+			name: "should_not_find_an_item_with_a_rate_that_is_not_a_number",
+			item: map[string]*dynamodb.AttributeValue{
+				"pair":       {S: aws.String("USD:COP")},
+				"rate":       {S: aws.String("4000")},
+				"fetched_at": {N: aws.String("1600000000")},
+			},
+			want:      Quote{},
+			wantFound: false,
+			wantErr:   false,
+		},
+		{
+			name: "should_return_error_because_rate_is_not_valid",
+			item: map[string]*dynamodb.AttributeValue{
+				"rate":       {N: aws.String("x")},
+				"fetched_at": {N: aws.String("1600000000")},
+			},
+			want:      Quote{},
+			wantFound: false,
+			wantErr:   true,
+		},
+		{
+			name: "should_return_the_quote",
+			item: map[string]*dynamodb.AttributeValue{
+				"pair":       {S: aws.String("USD:COP")},
+				"rate":       {N: aws.String("4000")},
+				"fetched_at": {N: aws.String("1600000000")},
+			},
+			want:      Quote{From: "USD", To: "COP", Rate: 4000, FetchedAt: time.Unix(1600000000, 0)},
+			wantFound: true,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := quoteOf("USD", "COP", tt.item)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("quoteOf() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if found != tt.wantFound {
+				t.Errorf("quoteOf() found = %v, want %v", found, tt.wantFound)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("quoteOf() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,20 +3,20 @@ package ctx
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
 	"github.com/sirupsen/logrus"
 )
 
 //beerRepositoryInterface contract for beer repository
 type beerRepositoryInterface interface {
-	Find(int) (model.Beer, error)
+	Find(context.Context, int) (model.Beer, error)
 }
 
 //Handler main struct for lambda
@@ -27,36 +27,48 @@ type Handler struct {
 
 //Handler main function for lambda
 func (h *Handler) Handler(
-	_ context.Context,
+	ctx context.Context,
 	req events.APIGatewayProxyRequest,
 ) (events.APIGatewayProxyResponse, error) {
 	IDString := req.PathParameters["beerID"]
+	logger := lib.Logger(ctx, h.logger).WithField("beerID", IDString)
+	logger.Info("Beginning of execution of lambda")
+
 	if strings.TrimSpace(IDString) == "" {
-		return lib.ResponseError(http.StatusBadRequest, errors.New("beerID_can_not_be_empty")), nil
+		return lib.ResponseError(req.Path, errors.Validation("beerID_can_not_be_empty")), nil
 	}
 
 	ID, err := strconv.Atoi(IDString)
 	if err != nil {
-		return lib.ResponseError(http.StatusBadRequest, errors.New("beerID_is_not_a_number")), nil
+		return lib.ResponseError(req.Path, errors.Validation("beerID_is_not_a_number")), nil
 
 	}
 
-	beer, err := h.beersRepository.Find(ID)
+	beer, err := h.beersRepository.Find(ctx, ID)
 	if err != nil {
-		return lib.ResponseError(http.StatusInternalServerError, err), nil
+		logger.WithError(err).Error("error finding beer")
+		return lib.ResponseError(req.Path, err), nil
 
 	}
 
 	if beer.ID == 0 {
-		return lib.ResponseError(http.StatusNotFound, errors.New("beerID_does_not_exist")), nil
+		return lib.ResponseError(req.Path, errors.NotFound("beerID_does_not_exist")), nil
+	}
+
+	if !beer.Active() && req.QueryStringParameters["include_inactive"] != "true" {
+		return lib.ResponseError(req.Path, errors.Gone("beerID_was_deleted")), nil
 	}
 
 	response, err := json.Marshal(beer)
 	if err != nil {
-		return lib.ResponseError(http.StatusInternalServerError, err), nil
+		return lib.ResponseError(req.Path, err), nil
 	}
 
-	return lib.JSONResponse(http.StatusOK, response), nil
+	resp := lib.JSONResponse(http.StatusOK, response)
+	if beer.Version > 0 {
+		resp.Headers["ETag"] = lib.ETag(beer.Version)
+	}
+	return resp, nil
 
 }
 
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 //beersRepositoryMock mock for represent beers repository
@@ -18,7 +20,7 @@ type beersRepositoryMock struct {
 	mock.Mock
 }
 
-func (b *beersRepositoryMock) Find(ID int) (model.Beer, error) {
+func (b *beersRepositoryMock) Find(_ context.Context, ID int) (model.Beer, error) {
 	args := b.Called(ID)
 	return args.Get(0).(model.Beer), args.Error(1)
 }
@@ -26,12 +28,24 @@ func (b *beersRepositoryMock) Find(ID int) (model.Beer, error) {
 //go:embed golden_files/successResponse.json
 var successResponse []byte
 
+//go:embed golden_files/versionedResponse.json
+var versionedResponse []byte
+
+//go:embed golden_files/inactiveResponse.json
+var inactiveResponse []byte
+
 func TestHandler_Handler(t *testing.T) {
+	deletedAt := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
 	headers := map[string]string{
 		"Content-Type":                     "application/json",
 		"Access-Control-Allow-Origin":      "*",
 		"Access-Control-Allow-Credentials": "true",
 	}
+	problemHeaders := map[string]string{
+		"Content-Type":                     "application/problem+json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
 
 	type mocks struct {
 		beersRepository *beersRepositoryMock
@@ -72,8 +86,8 @@ func TestHandler_Handler(t *testing.T) {
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusBadRequest,
-				Headers:         headers,
-				Body:            `{"message":"beerID_can_not_be_empty"}`,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/beerID_can_not_be_empty","title":"Bad Request","status":400,"detail":"beerID_can_not_be_empty"}`,
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
@@ -97,8 +111,8 @@ func TestHandler_Handler(t *testing.T) {
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusBadRequest,
-				Headers:         headers,
-				Body:            `{"message":"beerID_is_not_a_number"}`,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/beerID_is_not_a_number","title":"Bad Request","status":400,"detail":"beerID_is_not_a_number"}`,
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
@@ -124,8 +138,8 @@ func TestHandler_Handler(t *testing.T) {
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusInternalServerError,
-				Headers:         headers,
-				Body:            `{"message":"error"}`,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
@@ -151,8 +165,8 @@ func TestHandler_Handler(t *testing.T) {
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode:      http.StatusNotFound,
-				Headers:         headers,
-				Body:            `{"message":"beerID_does_not_exist"}`,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/beerID_does_not_exist","title":"Not Found","status":404,"detail":"beerID_does_not_exist"}`,
 				IsBase64Encoded: false,
 			},
 			wantErr: false,
@@ -172,7 +186,7 @@ func TestHandler_Handler(t *testing.T) {
 						Name:     "Pilsen",
 						Brewery:  "Bavaria",
 						Country:  "Colombia",
-						Price:    2400,
+						Price:    money.NewDecimal(2400, 0),
 						Currency: "COP",
 					}, nil).Once()
 			},
@@ -192,6 +206,129 @@ func TestHandler_Handler(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should_return_a_success_response_with_etag",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(
+					model.Beer{
+						ID:       1,
+						Name:     "Pilsen",
+						Brewery:  "Bavaria",
+						Country:  "Colombia",
+						Price:    money.NewDecimal(2400, 0),
+						Currency: "COP",
+						Version:  3,
+					}, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers: map[string]string{
+					"Content-Type":                     "application/json",
+					"Access-Control-Allow-Origin":      "*",
+					"Access-Control-Allow-Credentials": "true",
+					"ETag":                             `"3"`,
+				},
+				Body:            string(versionedResponse),
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_gone_because_beer_was_deleted",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(
+					model.Beer{
+						ID:        1,
+						Name:      "Pilsen",
+						Brewery:   "Bavaria",
+						Country:   "Colombia",
+						Price:     money.NewDecimal(2400, 0),
+						Currency:  "COP",
+						Version:   2,
+						DeletedAt: &deletedAt,
+					}, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode:      http.StatusGone,
+				Headers:         problemHeaders,
+				Body:            `{"type":"/problems/beerID_was_deleted","title":"Gone","status":410,"detail":"beerID_was_deleted"}`,
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_a_deleted_beer_when_inactive_are_included",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(
+					model.Beer{
+						ID:        1,
+						Name:      "Pilsen",
+						Brewery:   "Bavaria",
+						Country:   "Colombia",
+						Price:     money.NewDecimal(2400, 0),
+						Currency:  "COP",
+						Version:   2,
+						DeletedAt: &deletedAt,
+					}, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					QueryStringParameters: map[string]string{
+						"include_inactive": "true",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers: map[string]string{
+					"Content-Type":                     "application/json",
+					"Access-Control-Allow-Origin":      "*",
+					"Access-Control-Allow-Credentials": "true",
+					"ETag":                             `"2"`,
+				},
+				Body:            string(inactiveResponse),
+				IsBase64Encoded: false,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
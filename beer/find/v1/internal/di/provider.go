@@ -11,6 +11,9 @@ import (
 )
 
 func providerAWSConfig() []*aws.Config {
+	if endpoint := os.Getenv("DYNAMODB_ENDPOINT"); endpoint != "" {
+		return []*aws.Config{aws.NewConfig().WithEndpoint(endpoint)}
+	}
 	return nil
 }
 
@@ -0,0 +1,70 @@
+package lib
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/sirupsen/logrus"
+)
+
+//CorrelationHeader header carrying the ID shared by every log line of a request
+const CorrelationHeader = "X-Correlation-ID"
+
+//HandlerFunc signature of the handlers of the lambdas behind API Gateway
+type HandlerFunc func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+//correlationKey key of the correlation ID in a context
+type correlationKey struct{}
+
+//CorrelationID ID of the request, the X-Correlation-ID sent by the client, else the ID API Gateway gave
+//to the request, else the ID of the lambda invocation
+func CorrelationID(ctx context.Context, req events.APIGatewayProxyRequest) string {
+	if ID := Header(req, CorrelationHeader); ID != "" {
+		return ID
+	}
+	if req.RequestContext.RequestID != "" {
+		return req.RequestContext.RequestID
+	}
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		return lc.AwsRequestID
+	}
+	return ""
+}
+
+//WithCorrelationID returns a copy of ctx carrying the correlation ID
+func WithCorrelationID(ctx context.Context, ID string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, ID)
+}
+
+//CorrelationIDFromContext correlation ID carried by ctx, empty when there is none
+func CorrelationIDFromContext(ctx context.Context) string {
+	ID, _ := ctx.Value(correlationKey{}).(string)
+	return ID
+}
+
+//Logger per-request entry of logger, it has the correlation ID of ctx when there is one
+func Logger(ctx context.Context, logger *logrus.Logger) *logrus.Entry {
+	if ID := CorrelationIDFromContext(ctx); ID != "" {
+		return logger.WithField("correlation_id", ID)
+	}
+	return logrus.NewEntry(logger)
+}
+
+//WithCorrelation middleware that puts the correlation ID of the request in the context given to next,
+//so Logger adds it to every log line, and echoes it in the X-Correlation-ID header of the response
+func WithCorrelation(next HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		ID := CorrelationID(ctx, req)
+		if ID == "" {
+			return next(ctx, req)
+		}
+
+		resp, err := next(WithCorrelationID(ctx, ID), req)
+		if resp.Headers == nil {
+			resp.Headers = map[string]string{}
+		}
+		resp.Headers[CorrelationHeader] = ID
+		return resp, err
+	}
+}
@@ -0,0 +1,85 @@
+package lib
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/sirupsen/logrus"
+)
+
+func TestWithCorrelation(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  events.APIGatewayProxyRequest
+		want string
+	}{
+		{
+			name: "should_prefer_the_correlation_id_of_the_client",
+			ctx:  context.Background(),
+			req: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"x-correlation-id": "client-id"},
+				RequestContext: events.APIGatewayProxyRequestContext{
+					RequestID: "gateway-id",
+				},
+			},
+			want: "client-id",
+		},
+		{
+			name: "should_use_the_api_gateway_request_id",
+			ctx:  context.Background(),
+			req: events.APIGatewayProxyRequest{
+				RequestContext: events.APIGatewayProxyRequestContext{
+					RequestID: "gateway-id",
+				},
+			},
+			want: "gateway-id",
+		},
+		{
+			name: "should_use_the_lambda_request_id",
+			ctx:  lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{AwsRequestID: "lambda-id"}),
+			req:  events.APIGatewayProxyRequest{},
+			want: "lambda-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&output)
+
+			handler := WithCorrelation(func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				Logger(ctx, logger).Info("finding beer")
+				return EmptyResponse(http.StatusNoContent), nil
+			})
+			resp, err := handler(tt.ctx, tt.req)
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			if got := resp.Headers[CorrelationHeader]; got != tt.want {
+				t.Errorf("header %s got = %s, want %s", CorrelationHeader, got, tt.want)
+			}
+			if !strings.Contains(output.String(), "correlation_id="+tt.want) {
+				t.Errorf("log %q does not have the correlation id %s", output.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWithCorrelation_withoutID(t *testing.T) {
+	handler := WithCorrelation(func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return EmptyResponse(http.StatusNoContent), nil
+	})
+	resp, err := handler(context.Background(), events.APIGatewayProxyRequest{})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if _, ok := resp.Headers[CorrelationHeader]; ok {
+		t.Errorf("header %s must not be set without an ID", CorrelationHeader)
+	}
+}
@@ -0,0 +1,72 @@
+package lib
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+)
+
+//ErrCursorNotValid custom error to represent a cursor that was altered or not issued by us
+var ErrCursorNotValid = errors.Validation("cursor_is_not_valid")
+
+//Cursor signs page keys so clients can move through a listing without reading or forging them
+type Cursor struct {
+	secret []byte
+}
+
+//Encode builds an opaque token from the last key of a page, an empty key means there are no more pages
+func (c *Cursor) Encode(key map[string]string) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	payload, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded)), nil
+}
+
+//Decode verifies the signature of a token and returns the page key it holds
+func (c *Cursor) Decode(token string) (map[string]string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrCursorNotValid
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, c.sign(parts[0])) {
+		return nil, ErrCursorNotValid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrCursorNotValid
+	}
+
+	var key map[string]string
+	if err = json.Unmarshal(payload, &key); err != nil || len(key) == 0 {
+		return nil, ErrCursorNotValid
+	}
+	return key, nil
+}
+
+//sign computes the HMAC-SHA256 of the encoded payload
+func (c *Cursor) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+//NewCursor construct for Cursor
+func NewCursor(secret []byte) *Cursor {
+	return &Cursor{
+		secret: secret,
+	}
+}
@@ -0,0 +1,67 @@
+package lib
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCursor_Decode(t *testing.T) {
+	key := map[string]string{"id": "2", "active": "1"}
+	cursor := NewCursor([]byte("secret"))
+	token, err := cursor.Encode(key)
+	if err != nil {
+		t.Fatalf("error encoding cursor %v", err)
+	}
+	forged, err := NewCursor([]byte("other")).Encode(key)
+	if err != nil {
+		t.Fatalf("error encoding cursor %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name:  "should_read_the_key_of_a_signed_cursor",
+			token: token,
+			want:  key,
+		},
+		{
+			name:    "should_fail_because_cursor_was_signed_with_another_secret",
+			token:   forged,
+			wantErr: ErrCursorNotValid,
+		},
+		{
+			name:    "should_fail_because_payload_was_altered",
+			token:   "e30" + token[3:],
+			wantErr: ErrCursorNotValid,
+		},
+		{
+			name:    "should_fail_because_cursor_is_not_a_token",
+			token:   "page-2",
+			wantErr: ErrCursorNotValid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cursor.Decode(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursor_Encode_lastPage(t *testing.T) {
+	token, err := NewCursor([]byte("secret")).Encode(nil)
+	if err != nil || token != "" {
+		t.Errorf("Encode() of an empty key must return an empty token but got %q, %v", token, err)
+	}
+}
@@ -0,0 +1,217 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+//Kind category of a domain error, it decides the status code of the response
+type Kind int
+
+const (
+	//KindInternal unexpected failure, its cause is never shown to clients
+	KindInternal Kind = iota
+	//KindValidation the request is not valid
+	KindValidation
+	//KindNotFound the resource does not exist
+	KindNotFound
+	//KindMethodNotAllowed the resource does not accept the method of the request
+	KindMethodNotAllowed
+	//KindGone the resource existed but it was deleted
+	KindGone
+	//KindConflict the request clashes with the current state of the resource
+	KindConflict
+	//KindPreconditionFailed the resource changed since the version the client expected
+	KindPreconditionFailed
+	//KindPreconditionRequired the request must say which version of the resource it expects
+	KindPreconditionRequired
+	//KindUpstream a service the request depends on failed
+	KindUpstream
+	//KindTimeout the request was not answered before its deadline
+	KindTimeout
+)
+
+//codeInternal code shown to clients for every internal error
+const codeInternal = "internal_error"
+
+//statuses status code of each kind
+var statuses = map[Kind]int{
+	KindInternal:             http.StatusInternalServerError,
+	KindValidation:           http.StatusBadRequest,
+	KindNotFound:             http.StatusNotFound,
+	KindMethodNotAllowed:     http.StatusMethodNotAllowed,
+	KindGone:                 http.StatusGone,
+	KindConflict:             http.StatusConflict,
+	KindPreconditionFailed:   http.StatusPreconditionFailed,
+	KindPreconditionRequired: http.StatusPreconditionRequired,
+	KindUpstream:             http.StatusBadGateway,
+	KindTimeout:              http.StatusGatewayTimeout,
+}
+
+//Field problem with one field of the request, Pointer is the JSON pointer (RFC 6901) of the field
+type Field struct {
+	Pointer string `json:"pointer"`
+	Code    string `json:"code"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+//Error domain error with a stable machine code, the cause is kept for logs
+type Error struct {
+	Kind   Kind
+	Code   string
+	Fields []Field
+	Cause  error
+}
+
+//Error code of the error followed by its fields or its cause when there are
+func (e *Error) Error() string {
+	if len(e.Fields) > 0 {
+		problems := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			problems[i] = f.Pointer + " " + f.Code
+		}
+		return e.Code + ": " + strings.Join(problems, ", ")
+	}
+	if e.Cause != nil {
+		return e.Code + ": " + e.Cause.Error()
+	}
+	return e.Code
+}
+
+//Unwrap cause of the error
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+//Is two domain errors are the same when they have the same kind and code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Code == e.Code
+}
+
+//Validation error of a request that is not valid
+func Validation(code string) *Error {
+	return &Error{Kind: KindValidation, Code: code}
+}
+
+//Invalid validation error listing every field that is not valid
+func Invalid(code string, fields []Field) *Error {
+	return &Error{Kind: KindValidation, Code: code, Fields: fields}
+}
+
+//NotFound error of a resource that does not exist
+func NotFound(code string) *Error {
+	return &Error{Kind: KindNotFound, Code: code}
+}
+
+//MethodNotAllowed error of a request whose method the resource does not accept
+func MethodNotAllowed(code string) *Error {
+	return &Error{Kind: KindMethodNotAllowed, Code: code}
+}
+
+//Gone error of a resource that was deleted
+func Gone(code string) *Error {
+	return &Error{Kind: KindGone, Code: code}
+}
+
+//Conflict error of a request that clashes with the current state of the resource
+func Conflict(code string) *Error {
+	return &Error{Kind: KindConflict, Code: code}
+}
+
+//PreconditionFailed error of a resource that changed since the version the client expected
+func PreconditionFailed(code string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Code: code}
+}
+
+//PreconditionRequired error of a change sent without the version of the resource it expects
+func PreconditionRequired(code string) *Error {
+	return &Error{Kind: KindPreconditionRequired, Code: code}
+}
+
+//Upstream error of a service the request depends on, cause may be nil
+func Upstream(code string, cause error) *Error {
+	return &Error{Kind: KindUpstream, Code: code, Cause: cause}
+}
+
+//Timeout error of a request that was not answered before its deadline, cause may be nil
+func Timeout(code string, cause error) *Error {
+	return &Error{Kind: KindTimeout, Code: code, Cause: cause}
+}
+
+//ErrDeadlineExceeded error of a request whose context ran out of time before it was answered
+var ErrDeadlineExceeded = Timeout("request_timed_out", nil)
+
+//FromContext turns err into a timeout error keeping it as cause when ctx ran out of time,
+//otherwise err is returned as it is. Calls cancelled by a deadline fail with errors of their own
+//client so ctx is what tells them apart
+func FromContext(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() != context.DeadlineExceeded || KindOf(err) == KindTimeout {
+		return err
+	}
+	return Timeout(ErrDeadlineExceeded.Code, err)
+}
+
+//Internal wraps an unexpected error so only internal_error reaches the client
+func Internal(cause error) *Error {
+	return &Error{Kind: KindInternal, Code: codeInternal, Cause: cause}
+}
+
+//KindOf kind of the first domain error in the chain of err, errors that are not domain errors are internal
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+//CodeOf code of the first domain error in the chain of err, internal errors always give internal_error
+func CodeOf(err error) string {
+	var e *Error
+	if errors.As(err, &e) && e.Kind != KindInternal {
+		return e.Code
+	}
+	return codeInternal
+}
+
+//FieldsOf fields of the first domain error in the chain of err
+func FieldsOf(err error) []Field {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Fields
+	}
+	return nil
+}
+
+//KindOfStatus kind of the errors answered with status, statuses no kind uses are internal
+func KindOfStatus(status int) Kind {
+	for kind, s := range statuses {
+		if s == status {
+			return kind
+		}
+	}
+	return KindInternal
+}
+
+//Status status code of the response for err
+func Status(err error) int {
+	return statuses[KindOf(err)]
+}
+
+//New plain error, clients see it as internal_error, see errors.New
+func New(text string) error {
+	return errors.New(text)
+}
+
+//Is reports whether any error in the chain of err matches target, see errors.Is
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+//As finds the first error in the chain of err that matches target, see errors.As
+func As(err error, target interface{}) bool {
+	return errors.As(err, target)
+}
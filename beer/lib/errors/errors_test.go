@@ -0,0 +1,185 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStatusAndCodeOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "should_map_validation_to_bad_request",
+			err:        Validation("beerID_is_not_a_number"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "beerID_is_not_a_number",
+		},
+		{
+			name:       "should_map_not_found",
+			err:        NotFound("beerID_does_not_exist"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "beerID_does_not_exist",
+		},
+		{
+			name:       "should_map_method_not_allowed",
+			err:        MethodNotAllowed("method_not_allowed"),
+			wantStatus: http.StatusMethodNotAllowed,
+			wantCode:   "method_not_allowed",
+		},
+		{
+			name:       "should_map_gone",
+			err:        Gone("beerID_was_deleted"),
+			wantStatus: http.StatusGone,
+			wantCode:   "beerID_was_deleted",
+		},
+		{
+			name:       "should_map_conflict",
+			err:        Conflict("error_beer_already_created"),
+			wantStatus: http.StatusConflict,
+			wantCode:   "error_beer_already_created",
+		},
+		{
+			name:       "should_map_precondition_failed",
+			err:        PreconditionFailed("error_beer_version_conflict"),
+			wantStatus: http.StatusPreconditionFailed,
+			wantCode:   "error_beer_version_conflict",
+		},
+		{
+			name:       "should_map_precondition_required",
+			err:        PreconditionRequired("if_match_is_required"),
+			wantStatus: http.StatusPreconditionRequired,
+			wantCode:   "if_match_is_required",
+		},
+		{
+			name:       "should_map_upstream_to_bad_gateway",
+			err:        Upstream("currency_conversion_failed", errors.New("timeout")),
+			wantStatus: http.StatusBadGateway,
+			wantCode:   "currency_conversion_failed",
+		},
+		{
+			name:       "should_map_timeout_to_gateway_timeout",
+			err:        Timeout("request_timed_out", nil),
+			wantStatus: http.StatusGatewayTimeout,
+			wantCode:   "request_timed_out",
+		},
+		{
+			name:       "should_find_domain_error_wrapped",
+			err:        fmt.Errorf("finding beer: %w", NotFound("beerID_does_not_exist")),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "beerID_does_not_exist",
+		},
+		{
+			name:       "should_hide_cause_of_internal_error",
+			err:        Internal(errors.New("ResourceNotFoundException: table not found")),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal_error",
+		},
+		{
+			name:       "should_treat_plain_error_as_internal",
+			err:        errors.New("RequestError: send request failed"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal_error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Status(tt.err); got != tt.wantStatus {
+				t.Errorf("Status() got = %d, want %d", got, tt.wantStatus)
+			}
+			if got := CodeOf(tt.err); got != tt.wantCode {
+				t.Errorf("CodeOf() got = %s, want %s", got, tt.wantCode)
+			}
+			if got := KindOfStatus(tt.wantStatus); got != KindOf(tt.err) {
+				t.Errorf("KindOfStatus() got = %v, want %v", got, KindOf(tt.err))
+			}
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	err := Invalid("beer_is_not_valid", []Field{
+		{Pointer: "/name", Code: "string_gte"},
+		{Pointer: "/currency", Code: "currency_not_supported"},
+	})
+	want := "beer_is_not_valid: /name string_gte, /currency currency_not_supported"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() got = %s, want %s", got, want)
+	}
+	if got := FieldsOf(fmt.Errorf("creating beer: %w", err)); len(got) != 2 {
+		t.Errorf("FieldsOf() got = %v, want the 2 fields", got)
+	}
+
+	err = Upstream("currency_conversion_failed", errors.New("timeout"))
+	want = "currency_conversion_failed: timeout"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() got = %s, want %s", got, want)
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	err := fmt.Errorf("saving beer: %w", Conflict("error_beer_already_created"))
+	if !Is(err, Conflict("error_beer_already_created")) {
+		t.Errorf("errors with the same kind and code must match")
+	}
+	if Is(err, NotFound("error_beer_already_created")) {
+		t.Errorf("errors with another kind must not match")
+	}
+	if Is(err, Conflict("beerID_repeated_in_batch")) {
+		t.Errorf("errors with another code must not match")
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "should_keep_error_of_context_in_time",
+			ctx:        context.Background(),
+			err:        NotFound("beerID_does_not_exist"),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "should_turn_error_of_expired_context_into_timeout",
+			ctx:        expired,
+			err:        errors.New("RequestCanceled: request context canceled"),
+			wantStatus: http.StatusGatewayTimeout,
+		},
+		{
+			name:       "should_keep_error_of_cancelled_context",
+			ctx:        cancelled,
+			err:        errors.New("RequestCanceled: request context canceled"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromContext(tt.ctx, tt.err)
+			if got := Status(err); got != tt.wantStatus {
+				t.Errorf("FromContext() status = %d, want %d", got, tt.wantStatus)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("FromContext() = %v, must keep %v as cause", err, tt.err)
+			}
+		})
+	}
+	if FromContext(expired, nil) != nil {
+		t.Errorf("FromContext() must keep nil errors")
+	}
+}
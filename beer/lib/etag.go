@@ -0,0 +1,17 @@
+package lib
+
+import (
+	"strconv"
+	"strings"
+)
+
+//ETag formats a beer version as a strong entity tag
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+//ParseETag reads the version from an entity tag like "3" or W/"3"
+func ParseETag(tag string) (int, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	return strconv.Atoi(strings.Trim(tag, `"`))
+}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/sirupsen/logrus"
+)
+
+//DefaultTimeout time a handler of the standard stack has to answer, API Gateway gives up after 29 seconds
+const DefaultTimeout = 28 * time.Second
+
+//deadlineMargin time kept before the deadline of the lambda invocation to send the timeout response
+const deadlineMargin = 200 * time.Millisecond
+
+//Middleware wraps a handler to add behaviour before or after it runs
+type Middleware func(lib.HandlerFunc) lib.HandlerFunc
+
+//Chain wraps handler with middlewares, the first one is the outermost so it runs first
+func Chain(handler lib.HandlerFunc, middlewares ...Middleware) lib.HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+//Standard stack of middlewares every lambda behind API Gateway opts into in its main
+func Standard(logger *logrus.Logger) []Middleware {
+	return []Middleware{
+		RequestID(),
+		AccessLog(logger),
+		CORS(DefaultCORS),
+		Recover(logger),
+		Timeout(DefaultTimeout),
+	}
+}
+
+//RequestID puts the correlation ID of the request in the context and echoes it in the response
+func RequestID() Middleware {
+	return lib.WithCorrelation
+}
+
+//Recover turns a panic of next into a 500 problem response and logs it with its stack
+func Recover(logger *logrus.Logger) Middleware {
+	return func(next lib.HandlerFunc) lib.HandlerFunc {
+		return func(ctx context.Context, req events.APIGatewayProxyRequest) (resp events.APIGatewayProxyResponse, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					lib.Logger(ctx, logger).WithField("stack", string(debug.Stack())).Errorf("panic: %v", r)
+					resp = lib.ResponseError(req.Path, errors.Internal(fmt.Errorf("panic: %v", r)))
+					err = nil
+				}
+			}()
+			return next(ctx, req)
+		}
+	}
+}
+
+//AccessLog logs method, path, status and latency of every request once next answers
+func AccessLog(logger *logrus.Logger) Middleware {
+	return func(next lib.HandlerFunc) lib.HandlerFunc {
+		return func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			entry := lib.Logger(ctx, logger).WithFields(logrus.Fields{
+				"method":     req.HTTPMethod,
+				"path":       req.Path,
+				"status":     resp.StatusCode,
+				"latency_ms": time.Since(start).Milliseconds(),
+			})
+			if err != nil {
+				entry.WithError(err).Error("request failed")
+				return resp, err
+			}
+			entry.Info("request served")
+			return resp, err
+		}
+	}
+}
+
+//CORSConfig headers CORS sets in every response
+type CORSConfig struct {
+	AllowOrigin      string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+//DefaultCORS configuration of the standard stack, it keeps the headers the lambdas always sent
+var DefaultCORS = CORSConfig{
+	AllowOrigin:      "*",
+	AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+	AllowHeaders:     []string{"Content-Type", "If-Match", lib.CorrelationHeader},
+	ExposeHeaders:    []string{"ETag", "Location", "Link", lib.CorrelationHeader},
+	AllowCredentials: true,
+	MaxAge:           10 * time.Minute,
+}
+
+//CORS sets the CORS headers of config in the response of next and answers preflight requests
+//with 204 without calling next
+func CORS(config CORSConfig) Middleware {
+	return func(next lib.HandlerFunc) lib.HandlerFunc {
+		return func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			if req.HTTPMethod == http.MethodOptions && lib.Header(req, "Access-Control-Request-Method") != "" {
+				return config.Preflight(), nil
+			}
+
+			resp, err := next(ctx, req)
+			if resp.Headers == nil {
+				resp.Headers = map[string]string{}
+			}
+			config.setHeaders(resp.Headers)
+			return resp, err
+		}
+	}
+}
+
+//Preflight 204 answering a preflight request with the methods and headers of c
+func (c CORSConfig) Preflight() events.APIGatewayProxyResponse {
+	resp := lib.EmptyResponse(http.StatusNoContent)
+	c.setHeaders(resp.Headers)
+	resp.Headers["Access-Control-Allow-Methods"] = strings.Join(c.AllowMethods, ", ")
+	resp.Headers["Access-Control-Allow-Headers"] = strings.Join(c.AllowHeaders, ", ")
+	if c.MaxAge > 0 {
+		resp.Headers["Access-Control-Max-Age"] = strconv.Itoa(int(c.MaxAge.Seconds()))
+	}
+	return resp
+}
+
+//setHeaders sets the headers shared by preflight and actual responses
+func (c CORSConfig) setHeaders(headers map[string]string) {
+	headers["Access-Control-Allow-Origin"] = c.AllowOrigin
+	if c.AllowCredentials {
+		headers["Access-Control-Allow-Credentials"] = "true"
+	}
+	if len(c.ExposeHeaders) > 0 {
+		headers["Access-Control-Expose-Headers"] = strings.Join(c.ExposeHeaders, ", ")
+	}
+}
+
+//result answer of a handler run by Timeout, panicked keeps what the handler panicked with
+type result struct {
+	resp     events.APIGatewayProxyResponse
+	err      error
+	panicked interface{}
+}
+
+//Timeout cancels the context of next after d, or before the lambda invocation runs out of time
+//when that comes first, and answers 504 when next has not answered by then. The context of next
+//expires deadlineMargin earlier so it still has time to answer with a partial result.
+//A panic of next is raised again in the caller so Recover still handles it
+func Timeout(d time.Duration) Middleware {
+	return func(next lib.HandlerFunc) lib.HandlerFunc {
+		return func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			deadline := time.Now().Add(d)
+			if lambdaDeadline, ok := ctx.Deadline(); ok && lambdaDeadline.Add(-deadlineMargin).Before(deadline) {
+				deadline = lambdaDeadline.Add(-deadlineMargin)
+			}
+			handlerCtx, cancel := context.WithDeadline(ctx, deadline.Add(-deadlineMargin))
+			defer cancel()
+			timer := time.NewTimer(time.Until(deadline))
+			defer timer.Stop()
+
+			done := make(chan result, 1)
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						done <- result{panicked: r}
+					}
+				}()
+				resp, err := next(handlerCtx, req)
+				done <- result{resp: resp, err: err}
+			}()
+
+			select {
+			case r := <-done:
+				if r.panicked != nil {
+					panic(r.panicked)
+				}
+				return r.resp, r.err
+			case <-timer.C:
+				return lib.ResponseError(req.Path, errors.ErrDeadlineExceeded), nil
+			case <-ctx.Done():
+				return lib.ResponseError(req.Path, errors.FromContext(ctx, ctx.Err())), nil
+			}
+		}
+	}
+}
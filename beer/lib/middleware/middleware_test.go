@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/sirupsen/logrus"
+)
+
+func TestChain(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next lib.HandlerFunc) lib.HandlerFunc {
+			return func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				calls = append(calls, name)
+				return next(ctx, req)
+			}
+		}
+	}
+	handler := Chain(func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		calls = append(calls, "handler")
+		return lib.EmptyResponse(http.StatusNoContent), nil
+	}, trace("first"), trace("second"))
+
+	if _, err := handler(context.Background(), events.APIGatewayProxyRequest{}); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if want := []string{"first", "second", "handler"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("Chain() calls = %v, want %v", calls, want)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var output bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&output)
+
+	handler := Recover(logger)(func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		panic("nil map")
+	})
+	resp, err := handler(context.Background(), events.APIGatewayProxyRequest{Path: "/beers/1"})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	want := events.APIGatewayProxyResponse{
+		StatusCode: http.StatusInternalServerError,
+		Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error","instance":"/beers/1"}`,
+		Headers: map[string]string{
+			"Content-Type":                     "application/problem+json",
+			"Access-Control-Allow-Origin":      "*",
+			"Access-Control-Allow-Credentials": "true",
+		},
+	}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("Recover() got = %v, want %v", resp, want)
+	}
+	if !strings.Contains(output.String(), "panic: nil map") {
+		t.Errorf("log %q does not have the panic", output.String())
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var output bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&output)
+
+	handler := Chain(func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return lib.EmptyResponse(http.StatusNoContent), nil
+	}, RequestID(), AccessLog(logger))
+	_, err := handler(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodDelete,
+		Path:       "/beers/1",
+		Headers:    map[string]string{lib.CorrelationHeader: "client-id"},
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	for _, want := range []string{"method=DELETE", "path=/beers/1", "status=204", "latency_ms=", "correlation_id=client-id"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("log %q does not have %s", output.String(), want)
+		}
+	}
+}
+
+func TestCORS(t *testing.T) {
+	called := false
+	handler := CORS(DefaultCORS)(func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		called = true
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+	})
+
+	resp, err := handler(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	want := map[string]string{
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Expose-Headers":    "ETag, Location, Link, X-Correlation-ID",
+	}
+	if !called || !reflect.DeepEqual(resp.Headers, want) {
+		t.Errorf("CORS() headers = %v, want %v", resp.Headers, want)
+	}
+
+	called = false
+	resp, err = handler(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodOptions,
+		Headers:    map[string]string{"Access-Control-Request-Method": http.MethodPatch},
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if called {
+		t.Errorf("CORS() must answer preflight requests without calling the handler")
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("CORS() preflight status = %d, want 204", resp.StatusCode)
+	}
+	if got := resp.Headers["Access-Control-Allow-Methods"]; got != "GET, POST, PUT, PATCH, DELETE" {
+		t.Errorf("CORS() preflight methods = %s", got)
+	}
+	if got := resp.Headers["Access-Control-Max-Age"]; got != "600" {
+		t.Errorf("CORS() preflight max age = %s, want 600", got)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	slow := func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		time.Sleep(time.Second)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+	}
+	partial := func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		select {
+		case <-time.After(time.Second):
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+		case <-ctx.Done():
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusPartialContent}, nil
+		}
+	}
+	tests := []struct {
+		name       string
+		ctx        func() (context.Context, context.CancelFunc)
+		timeout    time.Duration
+		handler    lib.HandlerFunc
+		wantStatus int
+	}{
+		{
+			name: "should_answer_before_the_timeout",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			timeout:    2 * time.Second,
+			handler:    partial,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "should_let_the_handler_answer_a_partial_result",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			timeout:    deadlineMargin + 10*time.Millisecond,
+			handler:    partial,
+			wantStatus: http.StatusPartialContent,
+		},
+		{
+			name: "should_return_gateway_timeout",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			timeout:    deadlineMargin + 10*time.Millisecond,
+			handler:    slow,
+			wantStatus: http.StatusGatewayTimeout,
+		},
+		{
+			name: "should_answer_before_the_lambda_deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 2*deadlineMargin+10*time.Millisecond)
+			},
+			timeout:    2 * time.Second,
+			handler:    slow,
+			wantStatus: http.StatusGatewayTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			start := time.Now()
+			resp, err := Timeout(tt.timeout)(tt.handler)(ctx, events.APIGatewayProxyRequest{Path: "/beers"})
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Timeout() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK && time.Since(start) > 900*time.Millisecond {
+				t.Errorf("Timeout() took %s", time.Since(start))
+			}
+		})
+	}
+}
+
+func TestStandard_recoversPanicBehindTimeout(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(&bytes.Buffer{})
+
+	handler := Chain(func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		panic("nil map")
+	}, Standard(logger)...)
+	resp, err := handler(context.Background(), events.APIGatewayProxyRequest{
+		Headers: map[string]string{lib.CorrelationHeader: "client-id"},
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Standard() status = %d, want 500", resp.StatusCode)
+	}
+	if got := resp.Headers[lib.CorrelationHeader]; got != "client-id" {
+		t.Errorf("Standard() header %s = %s, want client-id", lib.CorrelationHeader, got)
+	}
+	if got := resp.Headers["Access-Control-Expose-Headers"]; got == "" {
+		t.Errorf("Standard() must set the CORS headers")
+	}
+}
@@ -0,0 +1,270 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/routes"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+)
+
+//Version version of the API the document describes
+const Version = "1.0.0"
+
+const (
+	contentJSON    = "application/json"
+	contentProblem = "application/problem+json"
+)
+
+//Document OpenAPI 3 document, only the parts the beer API uses
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+//Info title and version of the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+//PathItem operations of a path by lowercase HTTP method
+type PathItem map[string]Operation
+
+//Operation one endpoint of the API, served by one lambda
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+//Parameter path or query parameter of an operation
+type Parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+	Schema      Schema `json:"schema"`
+}
+
+//RequestBody body an operation accepts
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+//Response answer of an operation for one status code
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+//Header header of a response
+type Header struct {
+	Description string `json:"description"`
+	Schema      Schema `json:"schema"`
+}
+
+//MediaType schema of a body for a content type
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+//Components schemas shared by the operations
+type Components struct {
+	Schemas map[string]Schema `json:"schemas"`
+}
+
+//Schema JSON schema kept raw so model.ValidationSchema is used as it is
+type Schema = json.RawMessage
+
+//ref schema pointing to a component
+func ref(name string) Schema {
+	return Schema(`{"$ref":"#/components/schemas/` + name + `"}`)
+}
+
+//jsonContent body of a JSON response with the schema of component name
+func jsonContent(name string) map[string]MediaType {
+	return map[string]MediaType{contentJSON: {Schema: ref(name)}}
+}
+
+//problem response with a Problem body
+func problem(description string) Response {
+	return Response{
+		Description: description,
+		Content:     map[string]MediaType{contentProblem: {Schema: ref("Problem")}},
+	}
+}
+
+//beerIDParameter path parameter of the endpoints of one beer
+var beerIDParameter = Parameter{
+	Name:     "beerID",
+	In:       "path",
+	Required: true,
+	Schema:   Schema(`{"type":"integer","minimum":1}`),
+}
+
+//etagHeader version of the beer, to send in If-Match when changing it
+var etagHeader = Header{
+	Description: "version of the beer, send it in If-Match to change it",
+	Schema:      Schema(`{"type":"string"}`),
+}
+
+//query optional query parameter with a string schema
+func query(name, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: Schema(`{"type":"string"}`)}
+}
+
+//New document of the create, find, list and box-price endpoints, Beer is model.ValidationSchema
+func New() Document {
+	return Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Beers API",
+			Description: "Catalog of beers and the price of a box of them in any currency",
+			Version:     Version,
+		},
+		Paths: map[string]PathItem{
+			routes.Beers: {
+				"get": {
+					OperationID: "listBeers",
+					Summary:     "List the active beers a page at a time",
+					Parameters: []Parameter{
+						query("cursor", "next_cursor of the previous page, the other parameters must not change"),
+						{Name: "limit", In: "query", Description: "beers per page", Schema: Schema(`{"type":"integer","minimum":1,"maximum":100,"default":20}`)},
+						{Name: "sort", In: "query", Description: "price and -price require currency, at most 1000 matching beers are sorted so filter the listing to sort more", Schema: Schema(`{"type":"string","enum":["price","-price","name","-name"]}`)},
+						query("country", "only beers of the country"),
+						query("brewery", "only beers of the brewery"),
+						query("name", "only beers whose name starts with it"),
+						query("currency", "only beers priced in the currency"),
+						{Name: "min_price", In: "query", Description: "requires currency", Schema: Schema(`{"type":"number"}`)},
+						{Name: "max_price", In: "query", Description: "requires currency", Schema: Schema(`{"type":"number"}`)},
+					},
+					Responses: map[string]Response{
+						"200": {
+							Description: "page of beers",
+							Headers: map[string]Header{
+								"Link": {Description: `link to the next page with rel="next"`, Schema: Schema(`{"type":"string"}`)},
+							},
+							Content: jsonContent("BeerPage"),
+						},
+						"400": problem("the query string is not valid"),
+					},
+				},
+				"post": {
+					OperationID: "createBeer",
+					Summary:     "Create a beer, the ID is given by the server when it is left out",
+					RequestBody: &RequestBody{Required: true, Content: jsonContent("Beer")},
+					Responses: map[string]Response{
+						"201": {
+							Description: "beer created",
+							Headers: map[string]Header{
+								"Location": {Description: "path of the beer", Schema: Schema(`{"type":"string"}`)},
+								"ETag":     etagHeader,
+							},
+							Content: jsonContent("StoredBeer"),
+						},
+						"400": problem("the beer is not valid, errors lists every field"),
+						"409": problem("there is already a beer with the ID"),
+					},
+				},
+			},
+			routes.Beer: {
+				"get": {
+					OperationID: "findBeer",
+					Summary:     "Find a beer by its ID",
+					Parameters: []Parameter{
+						beerIDParameter,
+						{Name: "include_inactive", In: "query", Description: "return the beer even if it was deleted", Schema: Schema(`{"type":"boolean"}`)},
+					},
+					Responses: map[string]Response{
+						"200": {
+							Description: "the beer",
+							Headers:     map[string]Header{"ETag": etagHeader},
+							Content:     jsonContent("StoredBeer"),
+						},
+						"400": problem("the ID is not valid"),
+						"404": problem("the beer does not exist"),
+						"410": problem("the beer was deleted"),
+					},
+				},
+			},
+			routes.BoxPrice: {
+				"get": {
+					OperationID: "boxPrice",
+					Summary:     "Price of a box of the beer in a currency",
+					Parameters: []Parameter{
+						beerIDParameter,
+						{Name: "currency", In: "query", Required: true, Schema: Schema(`{"type":"string","minLength":3,"maxLength":3}`)},
+						{Name: "quantity", In: "query", Description: "beers in the box", Schema: Schema(`{"type":"integer","minimum":1,"maximum":1000,"default":6}`)},
+					},
+					Responses: map[string]Response{
+						"200": {Description: "price of the box", Content: jsonContent("BoxPrice")},
+						"400": problem("the parameters are not valid or the rate of the currency is unknown"),
+						"404": problem("the beer does not exist"),
+						"410": problem("the beer was deleted"),
+						"502": problem("the currency api failed"),
+						"504": problem("the currency api did not answer in time"),
+					},
+				},
+			},
+			routes.OpenAPI: {
+				"get": {
+					OperationID: "openAPI",
+					Summary:     "This document",
+					Responses: map[string]Response{
+						"200": {Description: "OpenAPI document of the API", Content: map[string]MediaType{contentJSON: {Schema: Schema(`{"type":"object"}`)}}},
+					},
+				},
+			},
+		},
+		Components: Components{
+			Schemas: map[string]Schema{
+				"Beer": Schema(model.ValidationSchema),
+				"StoredBeer": Schema(`{"allOf":[{"$ref":"#/components/schemas/Beer"},{"type":"object","required":["id"],"properties":{` +
+					`"id":{"type":"integer","minimum":1},` +
+					`"version":{"type":"integer","minimum":1,"readOnly":true},` +
+					`"deleted_at":{"type":"string","format":"date-time","readOnly":true}}}]}`),
+				"BeerPage": Schema(`{"type":"object","required":["items"],"properties":{` +
+					`"items":{"type":"array","items":{"$ref":"#/components/schemas/StoredBeer"}},` +
+					`"next_cursor":{"type":"string"}}}`),
+				"BoxPrice": Schema(`{"type":"object","required":["price_total","currency","unit_price","quantity","rate","rate_stale"],"properties":{` +
+					`"price_total":{"type":"number"},` +
+					`"currency":{"type":"string","minLength":3,"maxLength":3},` +
+					`"unit_price":{"type":"number"},` +
+					`"quantity":{"type":"integer","minimum":1},` +
+					`"rate":{"type":"number"},` +
+					`"rate_stale":{"type":"boolean"}}}`),
+				"Problem": Schema(`{"type":"object","required":["type","title","status","detail"],"properties":{` +
+					`"type":{"type":"string"},` +
+					`"title":{"type":"string"},` +
+					`"status":{"type":"integer"},` +
+					`"detail":{"type":"string"},` +
+					`"instance":{"type":"string"},` +
+					`"errors":{"type":"array","items":{"$ref":"#/components/schemas/ProblemField"}}}}`),
+				"ProblemField": Schema(`{"type":"object","required":["pointer","code"],"properties":{` +
+					`"pointer":{"type":"string"},` +
+					`"code":{"type":"string"},` +
+					`"detail":{"type":"string"}}}`),
+			},
+		},
+	}
+}
+
+//Handler lambda handler answering GET routes.OpenAPI with the document
+func Handler(_ context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	body, err := json.Marshal(New())
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, err
+	}
+	return lib.JSONResponse(http.StatusOK, body), nil
+}
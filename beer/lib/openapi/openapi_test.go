@@ -0,0 +1,140 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/routes"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//responseSchema loader of the schema of a documented response, the components go along so its refs resolve
+func responseSchema(t *testing.T, path, method, status, contentType string) gojsonschema.JSONLoader {
+	t.Helper()
+	document := New()
+	operation, ok := document.Paths[path][method]
+	if !ok {
+		t.Fatalf("%s %s is not documented", method, path)
+	}
+	response, ok := operation.Responses[status]
+	if !ok {
+		t.Fatalf("%s %s does not document status %s", method, path, status)
+	}
+	media, ok := response.Content[contentType]
+	if !ok {
+		t.Fatalf("%s %s %s does not document %s", method, path, status, contentType)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(media.Schema, &schema); err != nil {
+		t.Fatalf("schema of %s %s %s is not valid JSON: %v", method, path, status, err)
+	}
+	schema["components"] = document.Components
+	return gojsonschema.NewGoLoader(schema)
+}
+
+//TestContract validates the golden responses of the handlers against the document
+func TestContract(t *testing.T) {
+	tests := []struct {
+		golden      string
+		path        string
+		method      string
+		status      string
+		contentType string
+	}{
+		{"create/v1/internal/ctx/golden_files/createdResponse.json", routes.Beers, "post", "201", contentJSON},
+		{"create/v1/internal/ctx/golden_files/invalidFieldsResponse.json", routes.Beers, "post", "400", contentProblem},
+		{"find/v1/internal/ctx/golden_files/successResponse.json", routes.Beer, "get", "200", contentJSON},
+		{"find/v1/internal/ctx/golden_files/versionedResponse.json", routes.Beer, "get", "200", contentJSON},
+		{"find/v1/internal/ctx/golden_files/inactiveResponse.json", routes.Beer, "get", "200", contentJSON},
+		{"list/v1/internal/ctx/golden_files/successResponse.json", routes.Beers, "get", "200", contentJSON},
+		{"list/v1/internal/ctx/golden_files/nextPageResponse.json", routes.Beers, "get", "200", contentJSON},
+		{"list/v1/internal/ctx/golden_files/sortedPageResponse.json", routes.Beers, "get", "200", contentJSON},
+		{"list/v1/internal/ctx/golden_files/sortedLastPageResponse.json", routes.Beers, "get", "200", contentJSON},
+		{"box-price/v1/internal/ctx/golden_files/successResponse.json", routes.BoxPrice, "get", "200", contentJSON},
+		{"box-price/v1/internal/ctx/golden_files/defaultQuantityResponse.json", routes.BoxPrice, "get", "200", contentJSON},
+		{"box-price/v1/internal/ctx/golden_files/staleRateResponse.json", routes.BoxPrice, "get", "200", contentJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			golden, err := os.ReadFile(filepath.Join("..", "..", filepath.FromSlash(tt.golden)))
+			if err != nil {
+				t.Fatalf("error reading golden file %v", err)
+			}
+
+			schema := responseSchema(t, tt.path, tt.method, tt.status, tt.contentType)
+			result, err := gojsonschema.Validate(schema, gojsonschema.NewBytesLoader(golden))
+			if err != nil {
+				t.Fatalf("error validating golden file %v", err)
+			}
+			for _, e := range result.Errors() {
+				t.Errorf("%s does not match %s %s %s: %s", tt.golden, tt.method, tt.path, tt.status, e)
+			}
+		})
+	}
+}
+
+func TestContract_rejectsResponseOutOfContract(t *testing.T) {
+	schema := responseSchema(t, routes.Beers, "get", "200", contentJSON)
+	result, err := gojsonschema.Validate(schema, gojsonschema.NewStringLoader(`{"items":[{"id":"1","name":"Pilsen"}]}`))
+	if err != nil {
+		t.Fatalf("error validating response %v", err)
+	}
+	if result.Valid() {
+		t.Errorf("a page with a string ID and no price must not match the document")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	resp, err := Handler(context.Background(), events.APIGatewayProxyRequest{Path: routes.OpenAPI})
+	if err != nil {
+		t.Fatalf("Handler() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Handler() status = %d, want 200", resp.StatusCode)
+	}
+
+	var document struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required []string `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &document); err != nil {
+		t.Fatalf("Handler() body is not JSON: %v", err)
+	}
+	if document.OpenAPI != "3.0.3" {
+		t.Errorf("Handler() openapi = %s, want 3.0.3", document.OpenAPI)
+	}
+	for _, path := range []string{routes.Beers, routes.Beer, routes.BoxPrice} {
+		if _, ok := document.Paths[path]; !ok {
+			t.Errorf("Handler() does not document %s", path)
+		}
+	}
+	if got := document.Components.Schemas["Beer"].Required; len(got) != 5 {
+		t.Errorf("Handler() Beer must be the validation schema, required = %v", got)
+	}
+}
+
+func TestNew_pathsAreRoutes(t *testing.T) {
+	deployed := map[string]bool{}
+	for _, route := range routes.All {
+		deployed[strings.ToLower(route.Method)+" "+route.Pattern] = true
+	}
+	for path, item := range New().Paths {
+		for method := range item {
+			if !deployed[method+" "+path] {
+				t.Errorf("New() documents %s %s but no lambda serves it", method, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package lib
+
+import (
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+//Header returns the value of a request header ignoring the case of its name
+func Header(req events.APIGatewayProxyRequest, name string) string {
+	if value, ok := req.Headers[name]; ok {
+		return value
+	}
+	for key, value := range req.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
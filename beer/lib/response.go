@@ -3,7 +3,10 @@ package lib
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
+
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
 )
 
 // EmptyResponse func for response
@@ -19,20 +22,42 @@ func EmptyResponse(statusCode int) events.APIGatewayProxyResponse {
 	return resp
 }
 
-//ResponseError function to build json error
-func ResponseError(statusCode int, err error) events.APIGatewayProxyResponse {
-	body, _ := json.Marshal(map[string]interface{}{
-		"message": err.Error(),
+//problemTypePrefix prefix of the type of a problem, the code of the error completes it
+const problemTypePrefix = "/problems/"
+
+//Problem body of an error response as defined by RFC 7807, Errors lists every field that is not valid
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   []errors.Field `json:"errors,omitempty"`
+}
+
+//ResponseError function to build an application/problem+json error, the status code comes from the
+//kind of err and the detail is its code so errors that are not domain errors only show internal_error.
+//instance is the path of the request, it is left out when empty
+func ResponseError(instance string, err error) events.APIGatewayProxyResponse {
+	status := errors.Status(err)
+	code := errors.CodeOf(err)
+	body, _ := json.Marshal(Problem{
+		Type:     problemTypePrefix + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   code,
+		Instance: instance,
+		Errors:   errors.FieldsOf(err),
 	})
 
 	var buf bytes.Buffer
 	json.HTMLEscape(&buf, body)
 	resp := events.APIGatewayProxyResponse{
-		StatusCode:      statusCode,
+		StatusCode:      status,
 		IsBase64Encoded: false,
 		Body:            buf.String(),
 		Headers: map[string]string{
-			"Content-Type":                     "application/json",
+			"Content-Type":                     "application/problem+json",
 			"Access-Control-Allow-Origin":      "*",
 			"Access-Control-Allow-Credentials": "true",
 		},
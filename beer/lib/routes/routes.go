@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//Path patterns of the http events of the lambdas, path parameters are written {name}
+const (
+	Beers      = "/v1"
+	BeersBatch = "/v1:batch"
+	Search     = "/v1/search"
+	Beer       = "/v1/{beerID}"
+	Restore    = "/v1/{beerID}/restore"
+	BoxPrice   = "/v1/{beerID}/boxprice"
+	ByBrewery  = "/v1/breweries/{brewery}/beers"
+	ByCountry  = "/v1/countries/{country}/beers"
+	OpenAPI    = "/openapi.json"
+)
+
+//Route method and path pattern of an http event of a lambda, Lambda is the folder of its serverless.yml
+type Route struct {
+	Lambda  string
+	Method  string
+	Pattern string
+}
+
+//All routes of the beer API, the same events the serverless.yml of every lambda declares
+var All = []Route{
+	{"list", http.MethodGet, Beers},
+	{"create", http.MethodPost, Beers},
+	{"create-batch", http.MethodPost, BeersBatch},
+	{"search", http.MethodGet, Search},
+	{"find", http.MethodGet, Beer},
+	{"update", http.MethodPut, Beer},
+	{"update", http.MethodPatch, Beer},
+	{"delete", http.MethodDelete, Beer},
+	{"delete", http.MethodPost, Restore},
+	{"box-price", http.MethodGet, BoxPrice},
+	{"list-by-brewery", http.MethodGet, ByBrewery},
+	{"list-by-country", http.MethodGet, ByCountry},
+	{"openapi", http.MethodGet, OpenAPI},
+}
+
+//BeerPath path of a pattern with the beerID parameter, like Beer or BoxPrice, for the beer ID
+func BeerPath(pattern string, ID int) string {
+	return strings.Replace(pattern, "{beerID}", strconv.Itoa(ID), 1)
+}
@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+//serverlessEvents method and path of the http events of a serverless.yml, the way they are written there
+func serverlessEvents(t *testing.T, file string) []Route {
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("error opening %s: %v", file, err)
+	}
+	defer f.Close()
+
+	var (
+		events []Route
+		path   string
+	)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "path:"):
+			path = strings.TrimSpace(strings.TrimPrefix(line, "path:"))
+		case strings.HasPrefix(line, "method:") && path != "":
+			method := strings.TrimSpace(strings.TrimPrefix(line, "method:"))
+			events = append(events, Route{Method: strings.ToUpper(method), Pattern: "/" + path})
+			path = ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("error reading %s: %v", file, err)
+	}
+	return events
+}
+
+func TestAll_matchesServerless(t *testing.T) {
+	files, err := filepath.Glob("../../*/serverless.yml")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("serverless.yml files not found: %v", err)
+	}
+
+	var want []Route
+	for _, file := range files {
+		lambda := filepath.Base(filepath.Dir(file))
+		for _, event := range serverlessEvents(t, file) {
+			event.Lambda = lambda
+			want = append(want, event)
+		}
+	}
+	got := append([]Route{}, All...)
+	for _, routes := range [][]Route{want, got} {
+		sort.Slice(routes, func(i, j int) bool {
+			return routes[i].Lambda+routes[i].Method+routes[i].Pattern < routes[j].Lambda+routes[j].Method+routes[j].Pattern
+		})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("All = %v, want the events of serverless.yml %v", got, want)
+	}
+}
+
+func TestBeerPath(t *testing.T) {
+	if got := BeerPath(BoxPrice, 7); got != "/v1/7/boxprice" {
+		t.Errorf("BeerPath() = %s, want /v1/7/boxprice", got)
+	}
+}
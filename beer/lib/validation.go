@@ -0,0 +1,73 @@
+package lib
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//codeBeerNotValid code of the error listing every field of a beer that is not valid
+const codeBeerNotValid = "beer_is_not_valid"
+
+//ErrPriceExceedsPrecision custom error to represent a price with more decimals than its currency allows
+var ErrPriceExceedsPrecision = errors.Validation("price_exceeds_currency_precision")
+
+//pointerEscaper escapes a member name to be used in a JSON pointer
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+//ValidateBeer checks beer against model.ValidationSchema, normalizes its currency
+//and checks its price fits in the minor units of that currency.
+//The error lists every field that is not valid, not only the first one
+func ValidateBeer(beer *model.Beer) error {
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewBytesLoader(model.ValidationSchema),
+		gojsonschema.NewGoLoader(beer),
+	)
+	if err != nil {
+		return err
+	}
+
+	fields := []errors.Field{}
+	invalid := map[string]bool{}
+	for _, e := range result.Errors() {
+		pointer := strings.TrimPrefix(e.Context().String("/"), "(root)")
+		if property, ok := e.Details()["property"].(string); ok && e.Type() == "required" {
+			pointer += "/" + pointerEscaper.Replace(property)
+		}
+		invalid[pointer] = true
+		fields = append(fields, errors.Field{
+			Pointer: pointer,
+			Code:    e.Type(),
+			Detail:  e.Description(),
+		})
+	}
+
+	if !invalid["/currency"] {
+		currency, err := money.NormalizeCurrency(beer.Currency)
+		if err != nil {
+			invalid["/currency"] = true
+			fields = append(fields, errors.Field{Pointer: "/currency", Code: errors.CodeOf(err)})
+		} else {
+			beer.Currency = currency
+		}
+	}
+
+	if !invalid["/currency"] && !invalid["/price"] {
+		_, err = beer.Money()
+		if err != nil {
+			fields = append(fields, errors.Field{Pointer: "/price", Code: ErrPriceExceedsPrecision.Code})
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Pointer < fields[j].Pointer
+	})
+	return errors.Invalid(codeBeerNotValid, fields)
+}
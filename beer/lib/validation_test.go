@@ -0,0 +1,93 @@
+package lib
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+)
+
+func TestValidateBeer(t *testing.T) {
+	tests := []struct {
+		name         string
+		beer         model.Beer
+		wantFields   []errors.Field
+		wantCurrency string
+	}{
+		{
+			name: "should_accept_beer_and_normalize_currency",
+			beer: model.Beer{
+				Name:     "Pilsen",
+				Brewery:  "Bavaria",
+				Country:  "Colombia",
+				Price:    money.NewDecimal(2400, 0),
+				Currency: "cop",
+			},
+			wantCurrency: "COP",
+		},
+		{
+			name: "should_list_every_invalid_field",
+			beer: model.Beer{
+				Name:     "P",
+				Brewery:  "Bavaria",
+				Country:  "C",
+				Currency: "COPS",
+			},
+			wantFields: []errors.Field{
+				{Pointer: "/country", Code: "string_gte", Detail: "String length must be greater than or equal to 2"},
+				{Pointer: "/currency", Code: "string_lte", Detail: "String length must be less than or equal to 3"},
+				{Pointer: "/name", Code: "string_gte", Detail: "String length must be greater than or equal to 2"},
+				{Pointer: "/price", Code: "number_gte", Detail: "Must be greater than or equal to 0.1"},
+			},
+			wantCurrency: "COPS",
+		},
+		{
+			name: "should_reject_currency_that_is_not_supported",
+			beer: model.Beer{
+				Name:     "Pilsen",
+				Brewery:  "Bavaria",
+				Country:  "Colombia",
+				Price:    money.NewDecimal(2400, 0),
+				Currency: "ABC",
+			},
+			wantFields: []errors.Field{
+				{Pointer: "/currency", Code: "currency_not_supported"},
+			},
+			wantCurrency: "ABC",
+		},
+		{
+			name: "should_reject_price_with_more_decimals_than_its_currency",
+			beer: model.Beer{
+				Name:     "Pilsen",
+				Brewery:  "Bavaria",
+				Country:  "Colombia",
+				Price:    money.NewDecimal(2400555, 3),
+				Currency: "COP",
+			},
+			wantFields: []errors.Field{
+				{Pointer: "/price", Code: "price_exceeds_currency_precision"},
+			},
+			wantCurrency: "COP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBeer(&tt.beer)
+			if (err != nil) != (tt.wantFields != nil) {
+				t.Fatalf("ValidateBeer() error = %v, want fields %v", err, tt.wantFields)
+			}
+			if got := errors.FieldsOf(err); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("ValidateBeer() fields = %v, want %v", got, tt.wantFields)
+			}
+			if err != nil && errors.Status(err) != http.StatusBadRequest {
+				t.Errorf("ValidateBeer() status = %d, want 400", errors.Status(err))
+			}
+			if tt.beer.Currency != tt.wantCurrency {
+				t.Errorf("ValidateBeer() currency = %s, want %s", tt.beer.Currency, tt.wantCurrency)
+			}
+		})
+	}
+}
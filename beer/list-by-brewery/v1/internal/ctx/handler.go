@@ -0,0 +1,76 @@
+package ctx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/sirupsen/logrus"
+)
+
+//beerRepositoryInterface contract for beer repository
+type beerRepositoryInterface interface {
+	ListByBrewery(context.Context, string) ([]model.Beer, error)
+}
+
+//responseLambda beers of the brewery
+type responseLambda struct {
+	Items []model.Beer `json:"items"`
+}
+
+//Handler main struct for lambda
+type Handler struct {
+	beersRepository beerRepositoryInterface
+	logger          *logrus.Logger
+}
+
+//Handler main function for lambda
+func (h *Handler) Handler(
+	ctx context.Context,
+	req events.APIGatewayProxyRequest,
+) (events.APIGatewayProxyResponse, error) {
+	brewery := req.PathParameters["brewery"]
+	if unescaped, err := url.PathUnescape(brewery); err == nil {
+		brewery = unescaped
+	}
+	if strings.TrimSpace(brewery) == "" {
+		return lib.ResponseError(req.Path, errors.Validation("brewery_can_not_be_empty")), nil
+	}
+
+	logger := lib.Logger(ctx, h.logger).WithField("brewery", brewery)
+	logger.Info("Beginning of execution of lambda")
+
+	beers, err := h.beersRepository.ListByBrewery(ctx, brewery)
+	if err != nil {
+		logger.WithError(err).Error("error finding beers of brewery")
+		return lib.ResponseError(req.Path, err), nil
+	}
+	if beers == nil {
+		beers = []model.Beer{}
+	}
+
+	response, err := json.Marshal(responseLambda{Items: beers})
+	if err != nil {
+		logger.WithError(err).Error("error marshaling beers")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	return lib.JSONResponse(http.StatusOK, response), nil
+}
+
+//NewHandler construct for Handler
+func NewHandler(
+	beersRepository beerRepositoryInterface,
+	logger *logrus.Logger,
+) *Handler {
+	return &Handler{
+		beersRepository: beersRepository,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,197 @@
+package ctx
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+//beerRepositoryMock struct to simulate beer repository behavior
+type beerRepositoryMock struct {
+	mock.Mock
+}
+
+func (b *beerRepositoryMock) ListByBrewery(_ context.Context, brewery string) ([]model.Beer, error) {
+	args := b.Called(brewery)
+	return args.Get(0).([]model.Beer), args.Error(1)
+}
+
+//go:embed golden_files/successResponse.json
+var successResponse []byte
+
+func TestHandler_Handler(t *testing.T) {
+	beersToReturn := []model.Beer{
+		{
+			ID:       1,
+			Name:     "Pilsen",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(2400, 0),
+			Currency: "COP",
+		},
+		{
+			ID:       2,
+			Name:     "Poker",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(2000, 0),
+			Currency: "COP",
+		},
+	}
+	headers := map[string]string{
+		"Content-Type":                     "application/json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+	problemHeaders := map[string]string{
+		"Content-Type":                     "application/problem+json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+
+	type mocks struct {
+		beersRepository *beerRepositoryMock
+	}
+	type fields struct {
+		logger *logrus.Logger
+	}
+	type args struct {
+		ctx context.Context
+		req events.APIGatewayProxyRequest
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		mocks   mocks
+		mocker  func(m mocks)
+		want    events.APIGatewayProxyResponse
+		wantErr bool
+	}{
+		{
+			name: "should_return_error_because_brewery_is_not_present",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/brewery_can_not_be_empty","title":"Bad Request","status":400,"detail":"brewery_can_not_be_empty"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_listing_beers",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"brewery": "Bavaria",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("ListByBrewery", "Bavaria").Return([]model.Beer{}, errors.New("error")).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_an_empty_list_because_brewery_has_no_beers",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"brewery": "Club%20Colombia",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("ListByBrewery", "Club Colombia").Return([]model.Beer{}, nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       `{"items":[]}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_an_success_response",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					PathParameters: map[string]string{
+						"brewery": "Bavaria",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("ListByBrewery", "Bavaria").Return(beersToReturn, nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       string(successResponse),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocker(tt.mocks)
+			h := NewHandler(tt.mocks.beersRepository, tt.fields.logger)
+			got, err := h.Handler(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Handler() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Handler() got = %v, want %v", got, tt.want)
+			}
+			tt.mocks.beersRepository.AssertExpectations(t)
+		})
+	}
+}
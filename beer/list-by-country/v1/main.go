@@ -0,0 +1,16 @@
+package main
+
+import (
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/middleware"
+	"github.com/chandy20/prueba-smartjobandina/beer/list-by-country/v1/internal/di"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	handler, err := di.Initialize()
+	if err != nil {
+		panic("fatal err: " + err.Error())
+	}
+	lambda.Start(middleware.Chain(handler.Handler, middleware.Standard(logrus.StandardLogger())...))
+}
@@ -0,0 +1,32 @@
+//Package api exposes the handler of the list lambda to run it outside of API Gateway, see cmd/localapi
+package api
+
+import (
+	"context"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/list/v1/internal/ctx"
+	"github.com/chandy20/prueba-smartjobandina/beer/list/v1/internal/di"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	"github.com/sirupsen/logrus"
+)
+
+//Repository beers the handler reads, every repository.Beers satisfies it
+type Repository interface {
+	ListPage(context.Context, repository.ListFilter, int, map[string]string) ([]model.Beer, map[string]string, error)
+}
+
+//New builds the handler of the lambda with the same dependencies its main uses
+func New() (lib.HandlerFunc, error) {
+	handler, err := di.Initialize()
+	if err != nil {
+		return nil, err
+	}
+	return handler.Handler, nil
+}
+
+//NewHandler builds the handler over repository, cursor signs the next_cursor of the pages
+func NewHandler(repository Repository, cursor *lib.Cursor, logger *logrus.Logger) lib.HandlerFunc {
+	return ctx.NewHandler(repository, cursor, logger).Handler
+}
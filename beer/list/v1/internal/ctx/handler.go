@@ -5,56 +5,176 @@ import (
 	"encoding/json"
 	"github.com/chandy20/prueba-smartjobandina/beer/lib"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
 	"github.com/sirupsen/logrus"
 )
 
+//maxSortedBeers most beers a sorted listing orders in memory, the indexes have no sort key so sorting
+//more needs a narrower filter
+const maxSortedBeers = 1000
+
+//errTooManyBeersToSort custom error to represent a sorted listing that matches more than maxSortedBeers
+var errTooManyBeersToSort = errors.Validation("too_many_beers_to_sort")
+
 //beerRepositoryInterface contract for beer repository
 type beerRepositoryInterface interface {
-	List() ([]model.Beer, error)
+	ListPage(context.Context, repository.ListFilter, int, map[string]string) ([]model.Beer, map[string]string, error)
+}
+
+//responseLambda page of beers with the cursor to request the next one
+type responseLambda struct {
+	Items      []model.Beer `json:"items"`
+	NextCursor string       `json:"next_cursor,omitempty"`
 }
 
 //Handler main struct for lambda
 type Handler struct {
 	beersRepository beerRepositoryInterface
+	cursor          *lib.Cursor
 	logger          *logrus.Logger
 }
 
-//Handler main function for lambda
+//Handler main function for lambda, filters are applied by dynamodb while sorted listings are
+//ordered in memory, up to maxSortedBeers, because the indexes have no sort key
 func (h *Handler) Handler(
-	_ context.Context,
+	ctx context.Context,
 	req events.APIGatewayProxyRequest,
 ) (events.APIGatewayProxyResponse, error) {
-	logger := h.logger.WithField("request_body", req.Body)
+	logger := lib.Logger(ctx, h.logger).WithField("request_body", req.Body)
 	logger.Info("Beginning of execution of lambda")
 
-	beers, err := h.beersRepository.List()
+	query, err := parseListQuery(req.QueryStringParameters)
+	if err != nil {
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	var startKey map[string]string
+	if cursor := req.QueryStringParameters["cursor"]; strings.TrimSpace(cursor) != "" {
+		startKey, err = h.cursor.Decode(cursor)
+		if err != nil {
+			logger.WithError(err).Error("error reading cursor")
+			return lib.ResponseError(req.Path, err), nil
+		}
+	}
+
+	var beers []model.Beer
+	var nextKey map[string]string
+	if query.sort == "" {
+		beers, nextKey, err = h.unsortedPage(ctx, query, startKey)
+	} else {
+		beers, nextKey, err = h.sortedPage(ctx, query, startKey)
+	}
 	if err != nil {
 		logger.WithError(err).Error("error finding beers")
-		return lib.ResponseError(http.StatusInternalServerError, err), nil
+		return lib.ResponseError(req.Path, err), nil
 	}
-	if len(beers) == 0 {
-		return lib.EmptyResponse(http.StatusAccepted), nil
+	if beers == nil {
+		beers = []model.Beer{}
 	}
 
-	response, err := json.Marshal(beers)
+	nextCursor, err := h.cursor.Encode(nextKey)
+	if err != nil {
+		logger.WithError(err).Error("error building cursor")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	response, err := json.Marshal(responseLambda{
+		Items:      beers,
+		NextCursor: nextCursor,
+	})
 	if err != nil {
 		logger.WithError(err).Error("error marshaling beers")
-		return lib.ResponseError(http.StatusInternalServerError, err), nil
+		return lib.ResponseError(req.Path, err), nil
 	}
 
-	return lib.JSONResponse(http.StatusOK, response), nil
+	resp := lib.JSONResponse(http.StatusOK, response)
+	if nextCursor != "" {
+		next := url.Values{}
+		for name, value := range req.QueryStringParameters {
+			next.Set(name, value)
+		}
+		next.Set("limit", strconv.Itoa(query.limit))
+		next.Set("cursor", nextCursor)
+		resp.Headers["Link"] = "<" + req.Path + "?" + next.Encode() + `>; rel="next"`
+	}
+	return resp, nil
+}
+
+//unsortedPage reads one page in the order of the index continuing from the key kept in the cursor
+func (h *Handler) unsortedPage(ctx context.Context, query listQuery, startKey map[string]string) ([]model.Beer, map[string]string, error) {
+	if _, ok := startKey["offset"]; ok {
+		return nil, nil, lib.ErrCursorNotValid
+	}
+	return h.beersRepository.ListPage(ctx, query.filter, query.limit, startKey)
+}
+
+//sortedPage reads every beer matching the filter, sorts them and cuts the page at the offset kept in the cursor
+func (h *Handler) sortedPage(ctx context.Context, query listQuery, startKey map[string]string) ([]model.Beer, map[string]string, error) {
+	offset := 0
+	if len(startKey) > 0 {
+		var err error
+		offset, err = strconv.Atoi(startKey["offset"])
+		if err != nil || offset < 0 || startKey["sort"] != query.sort {
+			return nil, nil, lib.ErrCursorNotValid
+		}
+	}
+
+	beers, err := h.matching(ctx, query.filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	sortBeers(beers, query.sort)
+
+	if offset >= len(beers) {
+		return []model.Beer{}, nil, nil
+	}
+	end := offset + query.limit
+	if end >= len(beers) {
+		return beers[offset:], nil, nil
+	}
+	return beers[offset:end], map[string]string{
+		"sort":   query.sort,
+		"offset": strconv.Itoa(end),
+	}, nil
+}
+
+//matching reads the beers of the filter a page at a time, it stops with errTooManyBeersToSort once
+//they are more than maxSortedBeers so a listing never reads the whole catalog
+func (h *Handler) matching(ctx context.Context, filter repository.ListFilter) ([]model.Beer, error) {
+	beers := []model.Beer{}
+	var startKey map[string]string
+	for {
+		page, next, err := h.beersRepository.ListPage(ctx, filter, maxSortedBeers+1, startKey)
+		if err != nil {
+			return nil, err
+		}
+		beers = append(beers, page...)
+		if len(beers) > maxSortedBeers {
+			return nil, errTooManyBeersToSort
+		}
+		if len(next) == 0 {
+			return beers, nil
+		}
+		startKey = next
+	}
 }
 
 //NewHandler construct for Handler
 func NewHandler(
 	beersRepository beerRepositoryInterface,
+	cursor *lib.Cursor,
 	logger *logrus.Logger,
 ) *Handler {
 	return &Handler{
 		beersRepository: beersRepository,
+		cursor:          cursor,
 		logger:          logger,
 	}
 }
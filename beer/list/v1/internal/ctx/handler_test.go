@@ -6,10 +6,14 @@ import (
 	"errors"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,14 +23,28 @@ type beerRepositoryMock struct {
 	mock.Mock
 }
 
-func (b *beerRepositoryMock) List() ([]model.Beer, error) {
-	args := b.Called()
-	return args.Get(0).([]model.Beer), args.Error(1)
+func (b *beerRepositoryMock) ListPage(
+	_ context.Context,
+	filter repository.ListFilter,
+	limit int,
+	startKey map[string]string,
+) ([]model.Beer, map[string]string, error) {
+	args := b.Called(filter, limit, startKey)
+	return args.Get(0).([]model.Beer), args.Get(1).(map[string]string), args.Error(2)
 }
 
 //go:embed golden_files/successResponse.json
 var successResponse []byte
 
+//go:embed golden_files/nextPageResponse.json
+var nextPageResponse []byte
+
+//go:embed golden_files/sortedPageResponse.json
+var sortedPageResponse []byte
+
+//go:embed golden_files/sortedLastPageResponse.json
+var sortedLastPageResponse []byte
+
 func TestHandler_Handler(t *testing.T) {
 	beersToReturn := []model.Beer{
 		{
@@ -34,7 +52,7 @@ func TestHandler_Handler(t *testing.T) {
 			Name:     "Pilsen",
 			Brewery:  "Bavaria",
 			Country:  "Colombia",
-			Price:    2400,
+			Price:    money.NewDecimal(2400, 0),
 			Currency: "COP",
 		},
 		{
@@ -42,7 +60,7 @@ func TestHandler_Handler(t *testing.T) {
 			Name:     "Brava",
 			Brewery:  "Bavaria",
 			Country:  "Colombia",
-			Price:    2000,
+			Price:    money.NewDecimal(2000, 0),
 			Currency: "COP",
 		},
 		{
@@ -50,7 +68,7 @@ func TestHandler_Handler(t *testing.T) {
 			Name:     "Corona",
 			Brewery:  "Bavaria",
 			Country:  "Mexico",
-			Price:    200,
+			Price:    money.NewDecimal(200, 0),
 			Currency: "MXN",
 		},
 		{
@@ -58,7 +76,7 @@ func TestHandler_Handler(t *testing.T) {
 			Name:     "Budweiser",
 			Brewery:  "Bavaria",
 			Country:  "Colombia",
-			Price:    2.5,
+			Price:    money.NewDecimal(25, 1),
 			Currency: "USD",
 		},
 		{
@@ -66,7 +84,7 @@ func TestHandler_Handler(t *testing.T) {
 			Name:     "Leona",
 			Brewery:  "Bavaria",
 			Country:  "Colombia",
-			Price:    2000,
+			Price:    money.NewDecimal(2000, 0),
 			Currency: "COP",
 		},
 		{
@@ -74,7 +92,7 @@ func TestHandler_Handler(t *testing.T) {
 			Name:     "Reds",
 			Brewery:  "Bavaria",
 			Country:  "Colombia",
-			Price:    3,
+			Price:    money.NewDecimal(3, 0),
 			Currency: "EUR",
 		},
 	}
@@ -83,6 +101,18 @@ func TestHandler_Handler(t *testing.T) {
 		"Access-Control-Allow-Origin":      "*",
 		"Access-Control-Allow-Credentials": "true",
 	}
+	problemHeaders := map[string]string{
+		"Content-Type":                     "application/problem+json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+
+	cursor := lib.NewCursor([]byte("secret"))
+	lastKey := map[string]string{"id": "2", "active": "1"}
+	nextCursor, _ := cursor.Encode(lastKey)
+	forgedCursor, _ := lib.NewCursor([]byte("other")).Encode(lastKey)
+	sortedCursor, _ := cursor.Encode(map[string]string{"sort": "-price", "offset": "2"})
+	minPrice := money.NewDecimal(2000, 0)
 
 	type mocks struct {
 		beersRepository *beerRepositoryMock
@@ -104,6 +134,81 @@ func TestHandler_Handler(t *testing.T) {
 		want    events.APIGatewayProxyResponse
 		wantErr bool
 	}{
+		{
+			name: "should_return_error_because_limit_is_not_a_number",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"limit": "a",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/limit_is_not_a_number","title":"Bad Request","status":400,"detail":"limit_is_not_a_number"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_limit_is_out_of_range",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"limit": "101",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/limit_is_out_of_range","title":"Bad Request","status":400,"detail":"limit_is_out_of_range"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_cursor_was_not_signed_by_us",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"cursor": forgedCursor,
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/cursor_is_not_valid","title":"Bad Request","status":400,"detail":"cursor_is_not_valid"}`,
+			},
+			wantErr: false,
+		},
 		{
 			name: "should_return_error_listing_beers",
 			fields: fields{
@@ -119,17 +224,43 @@ func TestHandler_Handler(t *testing.T) {
 				beersRepository: &beerRepositoryMock{},
 			},
 			mocker: func(m mocks) {
-				m.beersRepository.On("List").Return([]model.Beer{}, errors.New("error")).Once()
+				m.beersRepository.On("ListPage", repository.ListFilter{}, 20, map[string]string(nil)).
+					Return([]model.Beer{}, map[string]string(nil), errors.New("error")).Once()
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_an_empty_page_because_there_are_no_beers",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("ListPage", repository.ListFilter{}, 20, map[string]string(nil)).
+					Return([]model.Beer{}, map[string]string(nil), nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
 				Headers:    headers,
-				Body:       `{"message":"error"}`,
+				Body:       `{"items":[]}`,
 			},
 			wantErr: false,
 		},
 		{
-			name: "should_return_an_empty_response_because_there_are_no_beers",
+			name: "should_return_an_success_response",
 			fields: fields{
 				logger: logrus.New(),
 			},
@@ -143,18 +274,52 @@ func TestHandler_Handler(t *testing.T) {
 				beersRepository: &beerRepositoryMock{},
 			},
 			mocker: func(m mocks) {
-				m.beersRepository.On("List").Return([]model.Beer{}, nil).Once()
+				m.beersRepository.On("ListPage", repository.ListFilter{}, 20, map[string]string(nil)).
+					Return(beersToReturn, map[string]string(nil), nil).Once()
 			},
 			want: events.APIGatewayProxyResponse{
-				StatusCode: http.StatusAccepted,
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       string(successResponse),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_a_page_with_the_cursor_of_the_next_one",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Path:    "/v1",
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"limit": "2",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("ListPage", repository.ListFilter{}, 2, map[string]string(nil)).
+					Return(beersToReturn[:2], lastKey, nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
 				Headers: map[string]string{
-					"Content-Type": "text/plain",
+					"Content-Type":                     "application/json",
+					"Access-Control-Allow-Origin":      "*",
+					"Access-Control-Allow-Credentials": "true",
+					"Link":                             "</v1?cursor=" + nextCursor + `&limit=2>; rel="next"`,
 				},
+				Body: strings.Replace(string(nextPageResponse), "{cursor}", nextCursor, 1),
 			},
 			wantErr: false,
 		},
 		{
-			name: "should_return_an_success_response",
+			name: "should_continue_from_the_cursor",
 			fields: fields{
 				logger: logrus.New(),
 			},
@@ -162,18 +327,337 @@ func TestHandler_Handler(t *testing.T) {
 				ctx: context.Background(),
 				req: events.APIGatewayProxyRequest{
 					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"cursor": nextCursor,
+					},
 				},
 			},
 			mocks: mocks{
 				beersRepository: &beerRepositoryMock{},
 			},
 			mocker: func(m mocks) {
-				m.beersRepository.On("List").Return(beersToReturn, nil).Once()
+				m.beersRepository.On("ListPage", repository.ListFilter{}, 20, lastKey).
+					Return([]model.Beer{}, map[string]string(nil), nil).Once()
 			},
 			want: events.APIGatewayProxyResponse{
 				StatusCode: http.StatusOK,
 				Headers:    headers,
-				Body:       string(successResponse),
+				Body:       `{"items":[]}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_sort_is_not_valid",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"sort": "brewery",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/sort_is_not_valid","title":"Bad Request","status":400,"detail":"sort_is_not_valid"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_price_range_is_inverted",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"min_price": "10",
+						"max_price": "2.5",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/min_price_is_greater_than_max_price","title":"Bad Request","status":400,"detail":"min_price_is_greater_than_max_price"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_sort_by_price_has_no_currency",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"sort": "price",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/currency_is_required_to_compare_prices","title":"Bad Request","status":400,"detail":"currency_is_required_to_compare_prices"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_price_bound_has_no_currency",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"max_price": "2500",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/currency_is_required_to_compare_prices","title":"Bad Request","status":400,"detail":"currency_is_required_to_compare_prices"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_currency_is_not_supported",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"currency": "XYZ",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/currency_not_supported","title":"Bad Request","status":400,"detail":"currency_not_supported"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_pass_the_filters_to_the_repository",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"country":   "Colombia",
+						"brewery":   "Bavaria",
+						"currency":  "cop",
+						"name":      "P",
+						"min_price": "2000",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("ListPage", repository.ListFilter{
+					Country:    "Colombia",
+					Brewery:    "Bavaria",
+					Currency:   "COP",
+					NamePrefix: "P",
+					MinPrice:   &minPrice,
+				}, 20, map[string]string(nil)).Return(beersToReturn[:1], map[string]string(nil), nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       `{"items":[{"id":1,"name":"Pilsen","brewery":"Bavaria","country":"Colombia","price":2400,"currency":"COP"}]}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_the_first_page_sorted_by_price_descending",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Path:    "/v1",
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"sort":     "-price",
+						"limit":    "2",
+						"currency": "COP",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				beers := []model.Beer{beersToReturn[0], beersToReturn[1], beersToReturn[4]}
+				m.beersRepository.On("ListPage", repository.ListFilter{Currency: "COP"}, maxSortedBeers+1, map[string]string(nil)).Return(beers, map[string]string(nil), nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers: map[string]string{
+					"Content-Type":                     "application/json",
+					"Access-Control-Allow-Origin":      "*",
+					"Access-Control-Allow-Credentials": "true",
+					"Link":                             "</v1?currency=COP&cursor=" + sortedCursor + `&limit=2&sort=-price>; rel="next"`,
+				},
+				Body: strings.Replace(string(sortedPageResponse), "{cursor}", sortedCursor, 1),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_the_last_page_sorted_by_price_descending",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"sort":     "-price",
+						"limit":    "4",
+						"cursor":   sortedCursor,
+						"currency": "COP",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				beers := []model.Beer{beersToReturn[0], beersToReturn[1], beersToReturn[4]}
+				m.beersRepository.On("ListPage", repository.ListFilter{Currency: "COP"}, maxSortedBeers+1, map[string]string(nil)).Return(beers, map[string]string(nil), nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       string(sortedLastPageResponse),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_sort_beers_read_in_several_pages",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"sort":     "-price",
+						"limit":    "4",
+						"cursor":   sortedCursor,
+						"currency": "COP",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				filter := repository.ListFilter{Currency: "COP"}
+				m.beersRepository.On("ListPage", filter, maxSortedBeers+1, map[string]string(nil)).Return([]model.Beer{beersToReturn[4]}, lastKey, nil).Once()
+				m.beersRepository.On("ListPage", filter, maxSortedBeers+1, lastKey).Return([]model.Beer{beersToReturn[0], beersToReturn[1]}, map[string]string(nil), nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       string(sortedLastPageResponse),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_there_are_too_many_beers_to_sort",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"sort": "name",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("ListPage", repository.ListFilter{}, maxSortedBeers+1, map[string]string(nil)).Return(make([]model.Beer, maxSortedBeers+1), lastKey, nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/too_many_beers_to_sort","title":"Bad Request","status":400,"detail":"too_many_beers_to_sort"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_cursor_belongs_to_another_sort",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					Headers: headers,
+					QueryStringParameters: map[string]string{
+						"sort":   "name",
+						"cursor": sortedCursor,
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/cursor_is_not_valid","title":"Bad Request","status":400,"detail":"cursor_is_not_valid"}`,
 			},
 			wantErr: false,
 		},
@@ -181,7 +665,7 @@ func TestHandler_Handler(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mocker(tt.mocks)
-			h := NewHandler(tt.mocks.beersRepository, tt.fields.logger)
+			h := NewHandler(tt.mocks.beersRepository, cursor, tt.fields.logger)
 			got, err := h.Handler(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Handler() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,127 @@
+package ctx
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+var (
+	//errSortIsNotValid custom error to represent a sort different from price, -price, name or -name
+	errSortIsNotValid = errors.Validation("sort_is_not_valid")
+	//errCurrencyIsRequired custom error to represent prices compared without choosing their currency,
+	//amounts of different currencies can not be compared
+	errCurrencyIsRequired = errors.Validation("currency_is_required_to_compare_prices")
+)
+
+//listQuery filters, order and page size requested in the query string
+type listQuery struct {
+	filter repository.ListFilter
+	sort   string
+	limit  int
+}
+
+//parseListQuery reads and validates the query string of the list endpoint
+func parseListQuery(params map[string]string) (listQuery, error) {
+	query := listQuery{
+		filter: repository.ListFilter{
+			Country:    strings.TrimSpace(params["country"]),
+			Brewery:    strings.TrimSpace(params["brewery"]),
+			NamePrefix: strings.TrimSpace(params["name"]),
+		},
+		sort:  strings.TrimSpace(params["sort"]),
+		limit: defaultLimit,
+	}
+
+	if limitString := params["limit"]; strings.TrimSpace(limitString) != "" {
+		limit, err := strconv.Atoi(limitString)
+		if err != nil {
+			return listQuery{}, errors.Validation("limit_is_not_a_number")
+		}
+		if limit < 1 || limit > maxLimit {
+			return listQuery{}, errors.Validation("limit_is_out_of_range")
+		}
+		query.limit = limit
+	}
+
+	if currency := strings.TrimSpace(params["currency"]); currency != "" {
+		normalized, err := money.NormalizeCurrency(currency)
+		if err != nil {
+			return listQuery{}, err
+		}
+		query.filter.Currency = normalized
+	}
+
+	var err error
+	query.filter.MinPrice, err = parsePrice(params["min_price"], "min_price_is_not_a_number")
+	if err != nil {
+		return listQuery{}, err
+	}
+	query.filter.MaxPrice, err = parsePrice(params["max_price"], "max_price_is_not_a_number")
+	if err != nil {
+		return listQuery{}, err
+	}
+	if query.filter.MinPrice != nil && query.filter.MaxPrice != nil &&
+		query.filter.MinPrice.Cmp(*query.filter.MaxPrice) > 0 {
+		return listQuery{}, errors.Validation("min_price_is_greater_than_max_price")
+	}
+
+	switch query.sort {
+	case "", "price", "-price", "name", "-name":
+	default:
+		return listQuery{}, errSortIsNotValid
+	}
+
+	comparesPrices := query.sort == "price" || query.sort == "-price" ||
+		query.filter.MinPrice != nil || query.filter.MaxPrice != nil
+	if comparesPrices && query.filter.Currency == "" {
+		return listQuery{}, errCurrencyIsRequired
+	}
+
+	return query, nil
+}
+
+//parsePrice reads an optional price bound
+func parsePrice(value, message string) (*money.Decimal, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	price, err := money.ParseDecimal(strings.TrimSpace(value))
+	if err != nil {
+		return nil, errors.Validation(message)
+	}
+	return &price, nil
+}
+
+//sortBeers orders beers by price or case insensitive name, a leading - reverses the order.
+//Sorting by price expects beers of a single currency. Ties keep ascending ID so pages are stable between requests
+func sortBeers(beers []model.Beer, by string) {
+	descending := strings.HasPrefix(by, "-")
+	field := strings.TrimPrefix(by, "-")
+	sort.SliceStable(beers, func(i, j int) bool {
+		var cmp int
+		switch field {
+		case "price":
+			cmp = beers[i].Price.Cmp(beers[j].Price)
+		case "name":
+			cmp = strings.Compare(strings.ToLower(beers[i].Name), strings.ToLower(beers[j].Name))
+		}
+		if descending {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp < 0
+		}
+		return beers[i].ID < beers[j].ID
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
 	"github.com/chandy20/prueba-smartjobandina/beer/list/v1/internal/ctx"
 	"github.com/chandy20/prueba-smartjobandina/beer/repository"
 	"github.com/sirupsen/logrus"
@@ -11,6 +12,9 @@ import (
 )
 
 func providerAWSConfig() []*aws.Config {
+	if endpoint := os.Getenv("DYNAMODB_ENDPOINT"); endpoint != "" {
+		return []*aws.Config{aws.NewConfig().WithEndpoint(endpoint)}
+	}
 	return nil
 }
 
@@ -25,9 +29,18 @@ func providerBeerRepository(
 	return repository.NewBeerRepository(client, tableBeers, logger), nil
 }
 
+func providerCursor() (*lib.Cursor, error) {
+	secret := os.Getenv("CURSOR_SECRET")
+	if secret == "" {
+		return nil, errors.New("variable CURSOR_SECRET is not defined")
+	}
+	return lib.NewCursor([]byte(secret)), nil
+}
+
 func provideNewHandler(
 	beerRepository *repository.BeerRepository,
+	cursor *lib.Cursor,
 	logger *logrus.Logger,
 ) *ctx.Handler {
-	return ctx.NewHandler(beerRepository, logger)
+	return ctx.NewHandler(beerRepository, cursor, logger)
 }
@@ -1,6 +1,8 @@
 package di
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
 	"github.com/chandy20/prueba-smartjobandina/beer/list/v1/internal/ctx"
 	"github.com/chandy20/prueba-smartjobandina/beer/repository"
 	"os"
@@ -10,20 +12,28 @@ import (
 
 func Test_providerAwsSession(t *testing.T) {
 	tests := []struct {
-		name    string
-		wantErr bool
+		name         string
+		endpoint     string
+		wantEndpoint string
 	}{
 		{
-			name:    "should_build_aws_config_correctly",
-			wantErr: false,
+			name: "should_build_aws_config_correctly",
+		},
+		{
+			name:         "should_point_to_dynamodb_local",
+			endpoint:     "http://localhost:8000",
+			wantEndpoint: "http://localhost:8000",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := providerAWSConfig()
-			if tt.wantErr && err == nil {
-				t.Errorf("awsConfigProvider() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			t.Setenv("DYNAMODB_ENDPOINT", tt.endpoint)
+			got := ""
+			for _, config := range providerAWSConfig() {
+				got = aws.StringValue(config.Endpoint)
+			}
+			if got != tt.wantEndpoint {
+				t.Errorf("providerAWSConfig() endpoint = %v, want %v", got, tt.wantEndpoint)
 			}
 		})
 	}
@@ -69,6 +79,46 @@ func Test_providerBeerRepository(t *testing.T) {
 	}
 }
 
+func Test_providerCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       *lib.Cursor
+		setEnvVars func()
+		wantErr    bool
+	}{
+		{
+			name:       "should_fail_because_env_var_is_not_defined",
+			want:       nil,
+			setEnvVars: func() {},
+			wantErr:    true,
+		},
+		{
+			name: "should_build_cursor_correctly",
+			want: lib.NewCursor([]byte("some-secret")),
+			setEnvVars: func() {
+				err := os.Setenv("CURSOR_SECRET", "some-secret")
+				if err != nil {
+					t.Errorf("error setting env var %v", err)
+				}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setEnvVars()
+			got, err := providerCursor()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("providerCursor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("providerCursor() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_provideNewHandler(t *testing.T) {
 	tests := []struct {
 		name string
@@ -76,12 +126,12 @@ func Test_provideNewHandler(t *testing.T) {
 	}{
 		{
 			name: "should_build_handler_successfully",
-			want: ctx.NewHandler(nil, nil),
+			want: ctx.NewHandler(nil, nil, nil),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := provideNewHandler(nil, nil)
+			got := provideNewHandler(nil, nil, nil)
 
 			if reflect.TypeOf(got) != reflect.TypeOf(tt.want) {
 				t.Errorf("provideNewHandler() got = %v, want %v", got, tt.want)
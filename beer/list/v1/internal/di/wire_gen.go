@@ -27,6 +27,10 @@ func Initialize() (*ctx.Handler, error) {
 	if err != nil {
 		return nil, err
 	}
-	handler := provideNewHandler(beerRepository, logger)
+	cursor, err := providerCursor()
+	if err != nil {
+		return nil, err
+	}
+	handler := provideNewHandler(beerRepository, cursor, logger)
 	return handler, nil
 }
@@ -16,6 +16,7 @@ var stdSet = wire.NewSet(
 	dynamodb.New,
 	logrus.New,
 	providerBeerRepository,
+	providerCursor,
 	provideNewHandler,
 	providerAWSConfig,
 
@@ -2,7 +2,9 @@ package main
 
 import (
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/middleware"
 	"github.com/chandy20/prueba-smartjobandina/beer/list/v1/internal/di"
+	"github.com/sirupsen/logrus"
 )
 
 func main() {
@@ -10,5 +12,5 @@ func main() {
 	if err != nil {
 		panic("fatal err: " + err.Error())
 	}
-	lambda.Start(handler.Handler)
+	lambda.Start(middleware.Chain(handler.Handler, middleware.Standard(logrus.StandardLogger())...))
 }
@@ -0,0 +1,211 @@
+package localapi
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+//stage name of the stage of the requests served locally
+const stage = "local"
+
+//errRouteNotFound error of a request no route matches
+var errRouteNotFound = errors.NotFound("route_not_found")
+
+//Route lambda handler mounted on a method and a path pattern, path parameters are written {name}
+type Route struct {
+	Method  string
+	Pattern string
+	Handler lib.HandlerFunc
+}
+
+//Router http.Handler that runs the lambda handlers the way API Gateway does
+type Router struct {
+	routes []Route
+	logger *logrus.Logger
+}
+
+//ServeHTTP translates r into a proxy request for the handler of its route and writes back its response.
+//OPTIONS requests get the CORS preflight response and never reach a handler, like the OPTIONS
+//methods API Gateway adds to the resources with cors enabled
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		writeResponse(w, middleware.DefaultCORS.Preflight())
+		return
+	}
+
+	route, params, ok := rt.match(r.Method, r.URL.Path)
+	if !ok {
+		writeResponse(w, lib.ResponseError(r.URL.Path, errRouteNotFound))
+		return
+	}
+
+	req, err := Request(r, route.Pattern, params)
+	if err != nil {
+		rt.logger.WithError(err).Error("error reading request")
+		writeResponse(w, lib.ResponseError(r.URL.Path, errors.Validation("body_is_not_readable")))
+		return
+	}
+
+	resp, err := route.Handler(r.Context(), req)
+	if err != nil {
+		// API Gateway hides the error of a lambda behind a 502
+		rt.logger.WithError(err).WithField("path", r.URL.Path).Error("lambda returned an error")
+		writeResponse(w, lib.JSONResponse(http.StatusBadGateway, []byte(`{"message":"Internal server error"}`)))
+		return
+	}
+	writeResponse(w, resp)
+}
+
+//match finds the route of method and path, routes with more literal segments win so /v1/search
+//is not taken as the beer "search"
+func (rt *Router) match(method, path string) (Route, map[string]string, bool) {
+	var (
+		best       Route
+		bestParams map[string]string
+		found      bool
+		bestScore  = -1
+	)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, route := range rt.routes {
+		if route.Method != method {
+			continue
+		}
+		params, score, ok := matchPattern(route.Pattern, segments)
+		if ok && score > bestScore {
+			best, bestParams, bestScore, found = route, params, score, true
+		}
+	}
+	return best, bestParams, found
+}
+
+//matchPattern checks the segments of a path against pattern, score counts the literal segments
+func matchPattern(pattern string, segments []string) (map[string]string, int, bool) {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+	if len(parts) != len(segments) {
+		return nil, 0, false
+	}
+
+	var params map[string]string
+	score := 0
+	for i, part := range parts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if segments[i] == "" {
+				return nil, 0, false
+			}
+			if params == nil {
+				params = map[string]string{}
+			}
+			params[part[1:len(part)-1]] = segments[i]
+			continue
+		}
+		if part != segments[i] {
+			return nil, 0, false
+		}
+		score++
+	}
+	return params, score, true
+}
+
+//Request translates r into the proxy request API Gateway sends for the resource, headers and query
+//strings keep their last value in the single value maps. Bodies that are not UTF-8 go in base64
+func Request(r *http.Request, resource string, params map[string]string) (events.APIGatewayProxyRequest, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, err
+	}
+
+	req := events.APIGatewayProxyRequest{
+		Resource:       resource,
+		Path:           r.URL.Path,
+		HTTPMethod:     r.Method,
+		PathParameters: params,
+		RequestContext: events.APIGatewayProxyRequestContext{
+			RequestID:    requestID(),
+			Stage:        stage,
+			ResourcePath: resource,
+			HTTPMethod:   r.Method,
+		},
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		req.RequestContext.Identity.SourceIP = host
+	}
+
+	if len(r.Header) > 0 {
+		req.Headers = make(map[string]string, len(r.Header))
+		req.MultiValueHeaders = make(map[string][]string, len(r.Header))
+		for name, values := range r.Header {
+			req.Headers[name] = values[len(values)-1]
+			req.MultiValueHeaders[name] = values
+		}
+	}
+
+	if query := r.URL.Query(); len(query) > 0 {
+		req.QueryStringParameters = make(map[string]string, len(query))
+		req.MultiValueQueryStringParameters = query
+		for name, values := range query {
+			req.QueryStringParameters[name] = values[len(values)-1]
+		}
+	}
+
+	if utf8.Valid(body) {
+		req.Body = string(body)
+	} else {
+		req.Body = base64.StdEncoding.EncodeToString(body)
+		req.IsBase64Encoded = true
+	}
+	return req, nil
+}
+
+//requestID random ID like the ones API Gateway gives to every request
+func requestID() string {
+	ID := make([]byte, 16)
+	_, _ = rand.Read(ID)
+	return hex.EncodeToString(ID)
+}
+
+//writeResponse writes the proxy response of a lambda, base64 bodies are decoded
+func writeResponse(w http.ResponseWriter, resp events.APIGatewayProxyResponse) {
+	for name, value := range resp.Headers {
+		w.Header().Set(name, value)
+	}
+	for name, values := range resp.MultiValueHeaders {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+
+	body := []byte(resp.Body)
+	if resp.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(resp.Body)
+		if err == nil {
+			body = decoded
+		}
+	}
+
+	status := resp.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+//NewRouter construct for Router
+func NewRouter(routes []Route, logger *logrus.Logger) *Router {
+	return &Router{
+		routes: routes,
+		logger: logger,
+	}
+}
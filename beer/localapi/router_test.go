@@ -0,0 +1,217 @@
+package localapi
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+//echo handler answering the proxy request it got as JSON, the name tells which route was taken
+func echo(name string) lib.HandlerFunc {
+	return func(_ context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"route":     name,
+			"resource":  req.Resource,
+			"params":    req.PathParameters,
+			"query":     req.QueryStringParameters,
+			"body":      req.Body,
+			"base64":    req.IsBase64Encoded,
+			"if_match":  lib.Header(req, "If-Match"),
+			"requestID": req.RequestContext.RequestID != "",
+		})
+		return lib.JSONResponse(http.StatusOK, body), nil
+	}
+}
+
+func newTestRouter() *Router {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	return NewRouter([]Route{
+		{Method: http.MethodGet, Pattern: "/beers", Handler: echo("list")},
+		{Method: http.MethodPost, Pattern: "/beers", Handler: echo("create")},
+		{Method: http.MethodGet, Pattern: "/beers/{beerID}", Handler: echo("find")},
+		{Method: http.MethodGet, Pattern: "/beers/search", Handler: echo("search")},
+		{Method: http.MethodGet, Pattern: "/beers/{beerID}/boxprice", Handler: middleware.Chain(echo("box-price"), middleware.CORS(middleware.DefaultCORS))},
+		{Method: http.MethodDelete, Pattern: "/beers/{beerID}", Handler: func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			return events.APIGatewayProxyResponse{}, errors.New("nil map")
+		}},
+	}, logger)
+}
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		headers    map[string]string
+		wantStatus int
+		want       map[string]interface{}
+	}{
+		{
+			name:       "should_translate_path_params_and_query_strings",
+			method:     http.MethodGet,
+			target:     "/beers/1/boxprice?currency=USD&quantity=2&quantity=3",
+			wantStatus: http.StatusOK,
+			want: map[string]interface{}{
+				"route":     "box-price",
+				"resource":  "/beers/{beerID}/boxprice",
+				"params":    map[string]interface{}{"beerID": "1"},
+				"query":     map[string]interface{}{"currency": "USD", "quantity": "3"},
+				"body":      "",
+				"base64":    false,
+				"if_match":  "",
+				"requestID": true,
+			},
+		},
+		{
+			name:       "should_prefer_literal_segments_over_params",
+			method:     http.MethodGet,
+			target:     "/beers/search?q=pilsen",
+			wantStatus: http.StatusOK,
+			want: map[string]interface{}{
+				"route":     "search",
+				"resource":  "/beers/search",
+				"params":    nil,
+				"query":     map[string]interface{}{"q": "pilsen"},
+				"body":      "",
+				"base64":    false,
+				"if_match":  "",
+				"requestID": true,
+			},
+		},
+		{
+			name:       "should_pass_body_and_headers",
+			method:     http.MethodPost,
+			target:     "/beers",
+			body:       `{"name":"Pilsen"}`,
+			headers:    map[string]string{"If-Match": `"1"`},
+			wantStatus: http.StatusOK,
+			want: map[string]interface{}{
+				"route":     "create",
+				"resource":  "/beers",
+				"params":    nil,
+				"query":     nil,
+				"body":      `{"name":"Pilsen"}`,
+				"base64":    false,
+				"if_match":  `"1"`,
+				"requestID": true,
+			},
+		},
+		{
+			name:       "should_encode_binary_body_in_base64",
+			method:     http.MethodPost,
+			target:     "/beers",
+			body:       "\xff\xfe",
+			wantStatus: http.StatusOK,
+			want: map[string]interface{}{
+				"route":     "create",
+				"resource":  "/beers",
+				"params":    nil,
+				"query":     nil,
+				"body":      "//4=",
+				"base64":    true,
+				"if_match":  "",
+				"requestID": true,
+			},
+		},
+		{
+			name:       "should_return_not_found_for_unknown_path",
+			method:     http.MethodGet,
+			target:     "/breweries",
+			wantStatus: http.StatusNotFound,
+			want: map[string]interface{}{
+				"type":     "/problems/route_not_found",
+				"title":    "Not Found",
+				"status":   float64(http.StatusNotFound),
+				"detail":   "route_not_found",
+				"instance": "/breweries",
+			},
+		},
+		{
+			name:       "should_return_not_found_for_unknown_method",
+			method:     http.MethodPut,
+			target:     "/beers",
+			wantStatus: http.StatusNotFound,
+			want: map[string]interface{}{
+				"type":     "/problems/route_not_found",
+				"title":    "Not Found",
+				"status":   float64(http.StatusNotFound),
+				"detail":   "route_not_found",
+				"instance": "/beers",
+			},
+		},
+		{
+			name:       "should_hide_lambda_error_like_api_gateway",
+			method:     http.MethodDelete,
+			target:     "/beers/1",
+			wantStatus: http.StatusBadGateway,
+			want: map[string]interface{}{
+				"message": "Internal server error",
+			},
+		},
+	}
+	router := newTestRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("ServeHTTP() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var got map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("body %q is not JSON: %v", w.Body.String(), err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ServeHTTP() body = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouter_ServeHTTP_preflight(t *testing.T) {
+	r := httptest.NewRequest(http.MethodOptions, "/beers/1/boxprice", nil)
+	r.Header.Set("Origin", "http://localhost:8080")
+	r.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	w := httptest.NewRecorder()
+	newTestRouter().ServeHTTP(w, r)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("ServeHTTP() status = %d, want 204", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got == "" {
+		t.Errorf("preflight response must have Access-Control-Allow-Methods")
+	}
+}
+
+func TestRouter_ServeHTTP_options(t *testing.T) {
+	for _, target := range []string{"/beers/1", "/beers/1/restore", "/unknown"} {
+		r := httptest.NewRequest(http.MethodOptions, target, nil)
+		w := httptest.NewRecorder()
+		newTestRouter().ServeHTTP(w, r)
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("ServeHTTP() of OPTIONS %s status = %d, want 204 without running a handler", target, w.Code)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+			t.Errorf("ServeHTTP() of OPTIONS %s Access-Control-Allow-Origin = %q, want *", target, got)
+		}
+	}
+}
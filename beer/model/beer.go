@@ -1,11 +1,29 @@
 package model
 
-//Beer struct to represent a beer
+import (
+	"time"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+)
+
+//Beer struct to represent a beer, ID 0 means it was not given yet
 type Beer struct {
-	ID       int     `json:"id"`
-	Name     string  `json:"name"`
-	Brewery  string  `json:"brewery"`
-	Country  string  `json:"country"`
-	Price    float64 `json:"price"`
-	Currency string  `json:"currency"`
+	ID        int           `json:"id,omitempty"`
+	Name      string        `json:"name"`
+	Brewery   string        `json:"brewery"`
+	Country   string        `json:"country"`
+	Price     money.Decimal `json:"price"`
+	Currency  string        `json:"currency"`
+	Version   int           `json:"version,omitempty"`
+	DeletedAt *time.Time    `json:"deleted_at,omitempty"`
+}
+
+//Active indicates if the beer is listed, soft deleted beers keep their data with DeletedAt set
+func (b Beer) Active() bool {
+	return b.DeletedAt == nil
+}
+
+//Money price of the beer in minor units of its currency
+func (b Beer) Money() (money.Money, error) {
+	return money.New(b.Price, b.Currency)
 }
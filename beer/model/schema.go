@@ -0,0 +1,9 @@
+package model
+
+import (
+	_ "embed"
+)
+
+//ValidationSchema json schema a beer must satisfy to be saved
+//go:embed json_files/validation.json
+var ValidationSchema []byte
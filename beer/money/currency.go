@@ -0,0 +1,196 @@
+package money
+
+import (
+	"strings"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+)
+
+//ErrCurrencyNotSupported custom error to represent a code that is not in the ISO 4217 registry
+var ErrCurrencyNotSupported = errors.Validation("currency_not_supported")
+
+//Currency ISO 4217 currency with the number of decimals of its minor unit
+type Currency struct {
+	Code   string
+	Digits int32
+	Name   string
+}
+
+//currencies active ISO 4217 currencies indexed by code
+var currencies = map[string]Currency{
+	"AED": {Code: "AED", Digits: 2, Name: "UAE Dirham"},
+	"AFN": {Code: "AFN", Digits: 2, Name: "Afghani"},
+	"ALL": {Code: "ALL", Digits: 2, Name: "Lek"},
+	"AMD": {Code: "AMD", Digits: 2, Name: "Armenian Dram"},
+	"ANG": {Code: "ANG", Digits: 2, Name: "Netherlands Antillean Guilder"},
+	"AOA": {Code: "AOA", Digits: 2, Name: "Kwanza"},
+	"ARS": {Code: "ARS", Digits: 2, Name: "Argentine Peso"},
+	"AUD": {Code: "AUD", Digits: 2, Name: "Australian Dollar"},
+	"AWG": {Code: "AWG", Digits: 2, Name: "Aruban Florin"},
+	"AZN": {Code: "AZN", Digits: 2, Name: "Azerbaijan Manat"},
+	"BAM": {Code: "BAM", Digits: 2, Name: "Convertible Mark"},
+	"BBD": {Code: "BBD", Digits: 2, Name: "Barbados Dollar"},
+	"BDT": {Code: "BDT", Digits: 2, Name: "Taka"},
+	"BGN": {Code: "BGN", Digits: 2, Name: "Bulgarian Lev"},
+	"BHD": {Code: "BHD", Digits: 3, Name: "Bahraini Dinar"},
+	"BIF": {Code: "BIF", Digits: 0, Name: "Burundi Franc"},
+	"BMD": {Code: "BMD", Digits: 2, Name: "Bermudian Dollar"},
+	"BND": {Code: "BND", Digits: 2, Name: "Brunei Dollar"},
+	"BOB": {Code: "BOB", Digits: 2, Name: "Boliviano"},
+	"BRL": {Code: "BRL", Digits: 2, Name: "Brazilian Real"},
+	"BSD": {Code: "BSD", Digits: 2, Name: "Bahamian Dollar"},
+	"BTN": {Code: "BTN", Digits: 2, Name: "Ngultrum"},
+	"BWP": {Code: "BWP", Digits: 2, Name: "Pula"},
+	"BYN": {Code: "BYN", Digits: 2, Name: "Belarusian Ruble"},
+	"BZD": {Code: "BZD", Digits: 2, Name: "Belize Dollar"},
+	"CAD": {Code: "CAD", Digits: 2, Name: "Canadian Dollar"},
+	"CDF": {Code: "CDF", Digits: 2, Name: "Congolese Franc"},
+	"CHF": {Code: "CHF", Digits: 2, Name: "Swiss Franc"},
+	"CLF": {Code: "CLF", Digits: 4, Name: "Unidad de Fomento"},
+	"CLP": {Code: "CLP", Digits: 0, Name: "Chilean Peso"},
+	"CNY": {Code: "CNY", Digits: 2, Name: "Yuan Renminbi"},
+	"COP": {Code: "COP", Digits: 2, Name: "Colombian Peso"},
+	"COU": {Code: "COU", Digits: 2, Name: "Unidad de Valor Real"},
+	"CRC": {Code: "CRC", Digits: 2, Name: "Costa Rican Colon"},
+	"CUP": {Code: "CUP", Digits: 2, Name: "Cuban Peso"},
+	"CVE": {Code: "CVE", Digits: 2, Name: "Cabo Verde Escudo"},
+	"CZK": {Code: "CZK", Digits: 2, Name: "Czech Koruna"},
+	"DJF": {Code: "DJF", Digits: 0, Name: "Djibouti Franc"},
+	"DKK": {Code: "DKK", Digits: 2, Name: "Danish Krone"},
+	"DOP": {Code: "DOP", Digits: 2, Name: "Dominican Peso"},
+	"DZD": {Code: "DZD", Digits: 2, Name: "Algerian Dinar"},
+	"EGP": {Code: "EGP", Digits: 2, Name: "Egyptian Pound"},
+	"ERN": {Code: "ERN", Digits: 2, Name: "Nakfa"},
+	"ETB": {Code: "ETB", Digits: 2, Name: "Ethiopian Birr"},
+	"EUR": {Code: "EUR", Digits: 2, Name: "Euro"},
+	"FJD": {Code: "FJD", Digits: 2, Name: "Fiji Dollar"},
+	"FKP": {Code: "FKP", Digits: 2, Name: "Falkland Islands Pound"},
+	"GBP": {Code: "GBP", Digits: 2, Name: "Pound Sterling"},
+	"GEL": {Code: "GEL", Digits: 2, Name: "Lari"},
+	"GHS": {Code: "GHS", Digits: 2, Name: "Ghana Cedi"},
+	"GIP": {Code: "GIP", Digits: 2, Name: "Gibraltar Pound"},
+	"GMD": {Code: "GMD", Digits: 2, Name: "Dalasi"},
+	"GNF": {Code: "GNF", Digits: 0, Name: "Guinean Franc"},
+	"GTQ": {Code: "GTQ", Digits: 2, Name: "Quetzal"},
+	"GYD": {Code: "GYD", Digits: 2, Name: "Guyana Dollar"},
+	"HKD": {Code: "HKD", Digits: 2, Name: "Hong Kong Dollar"},
+	"HNL": {Code: "HNL", Digits: 2, Name: "Lempira"},
+	"HTG": {Code: "HTG", Digits: 2, Name: "Gourde"},
+	"HUF": {Code: "HUF", Digits: 2, Name: "Forint"},
+	"IDR": {Code: "IDR", Digits: 2, Name: "Rupiah"},
+	"ILS": {Code: "ILS", Digits: 2, Name: "New Israeli Sheqel"},
+	"INR": {Code: "INR", Digits: 2, Name: "Indian Rupee"},
+	"IQD": {Code: "IQD", Digits: 3, Name: "Iraqi Dinar"},
+	"IRR": {Code: "IRR", Digits: 2, Name: "Iranian Rial"},
+	"ISK": {Code: "ISK", Digits: 0, Name: "Iceland Krona"},
+	"JMD": {Code: "JMD", Digits: 2, Name: "Jamaican Dollar"},
+	"JOD": {Code: "JOD", Digits: 3, Name: "Jordanian Dinar"},
+	"JPY": {Code: "JPY", Digits: 0, Name: "Yen"},
+	"KES": {Code: "KES", Digits: 2, Name: "Kenyan Shilling"},
+	"KGS": {Code: "KGS", Digits: 2, Name: "Som"},
+	"KHR": {Code: "KHR", Digits: 2, Name: "Riel"},
+	"KMF": {Code: "KMF", Digits: 0, Name: "Comorian Franc"},
+	"KPW": {Code: "KPW", Digits: 2, Name: "North Korean Won"},
+	"KRW": {Code: "KRW", Digits: 0, Name: "Won"},
+	"KWD": {Code: "KWD", Digits: 3, Name: "Kuwaiti Dinar"},
+	"KYD": {Code: "KYD", Digits: 2, Name: "Cayman Islands Dollar"},
+	"KZT": {Code: "KZT", Digits: 2, Name: "Tenge"},
+	"LAK": {Code: "LAK", Digits: 2, Name: "Lao Kip"},
+	"LBP": {Code: "LBP", Digits: 2, Name: "Lebanese Pound"},
+	"LKR": {Code: "LKR", Digits: 2, Name: "Sri Lanka Rupee"},
+	"LRD": {Code: "LRD", Digits: 2, Name: "Liberian Dollar"},
+	"LSL": {Code: "LSL", Digits: 2, Name: "Loti"},
+	"LYD": {Code: "LYD", Digits: 3, Name: "Libyan Dinar"},
+	"MAD": {Code: "MAD", Digits: 2, Name: "Moroccan Dirham"},
+	"MDL": {Code: "MDL", Digits: 2, Name: "Moldovan Leu"},
+	"MGA": {Code: "MGA", Digits: 2, Name: "Malagasy Ariary"},
+	"MKD": {Code: "MKD", Digits: 2, Name: "Denar"},
+	"MMK": {Code: "MMK", Digits: 2, Name: "Kyat"},
+	"MNT": {Code: "MNT", Digits: 2, Name: "Tugrik"},
+	"MOP": {Code: "MOP", Digits: 2, Name: "Pataca"},
+	"MRU": {Code: "MRU", Digits: 2, Name: "Ouguiya"},
+	"MUR": {Code: "MUR", Digits: 2, Name: "Mauritius Rupee"},
+	"MVR": {Code: "MVR", Digits: 2, Name: "Rufiyaa"},
+	"MWK": {Code: "MWK", Digits: 2, Name: "Malawi Kwacha"},
+	"MXN": {Code: "MXN", Digits: 2, Name: "Mexican Peso"},
+	"MYR": {Code: "MYR", Digits: 2, Name: "Malaysian Ringgit"},
+	"MZN": {Code: "MZN", Digits: 2, Name: "Mozambique Metical"},
+	"NAD": {Code: "NAD", Digits: 2, Name: "Namibia Dollar"},
+	"NGN": {Code: "NGN", Digits: 2, Name: "Naira"},
+	"NIO": {Code: "NIO", Digits: 2, Name: "Cordoba Oro"},
+	"NOK": {Code: "NOK", Digits: 2, Name: "Norwegian Krone"},
+	"NPR": {Code: "NPR", Digits: 2, Name: "Nepalese Rupee"},
+	"NZD": {Code: "NZD", Digits: 2, Name: "New Zealand Dollar"},
+	"OMR": {Code: "OMR", Digits: 3, Name: "Rial Omani"},
+	"PAB": {Code: "PAB", Digits: 2, Name: "Balboa"},
+	"PEN": {Code: "PEN", Digits: 2, Name: "Sol"},
+	"PGK": {Code: "PGK", Digits: 2, Name: "Kina"},
+	"PHP": {Code: "PHP", Digits: 2, Name: "Philippine Peso"},
+	"PKR": {Code: "PKR", Digits: 2, Name: "Pakistan Rupee"},
+	"PLN": {Code: "PLN", Digits: 2, Name: "Zloty"},
+	"PYG": {Code: "PYG", Digits: 0, Name: "Guarani"},
+	"QAR": {Code: "QAR", Digits: 2, Name: "Qatari Rial"},
+	"RON": {Code: "RON", Digits: 2, Name: "Romanian Leu"},
+	"RSD": {Code: "RSD", Digits: 2, Name: "Serbian Dinar"},
+	"RUB": {Code: "RUB", Digits: 2, Name: "Russian Ruble"},
+	"RWF": {Code: "RWF", Digits: 0, Name: "Rwanda Franc"},
+	"SAR": {Code: "SAR", Digits: 2, Name: "Saudi Riyal"},
+	"SBD": {Code: "SBD", Digits: 2, Name: "Solomon Islands Dollar"},
+	"SCR": {Code: "SCR", Digits: 2, Name: "Seychelles Rupee"},
+	"SDG": {Code: "SDG", Digits: 2, Name: "Sudanese Pound"},
+	"SEK": {Code: "SEK", Digits: 2, Name: "Swedish Krona"},
+	"SGD": {Code: "SGD", Digits: 2, Name: "Singapore Dollar"},
+	"SHP": {Code: "SHP", Digits: 2, Name: "Saint Helena Pound"},
+	"SLE": {Code: "SLE", Digits: 2, Name: "Leone"},
+	"SOS": {Code: "SOS", Digits: 2, Name: "Somali Shilling"},
+	"SRD": {Code: "SRD", Digits: 2, Name: "Surinam Dollar"},
+	"SSP": {Code: "SSP", Digits: 2, Name: "South Sudanese Pound"},
+	"STN": {Code: "STN", Digits: 2, Name: "Dobra"},
+	"SVC": {Code: "SVC", Digits: 2, Name: "El Salvador Colon"},
+	"SYP": {Code: "SYP", Digits: 2, Name: "Syrian Pound"},
+	"SZL": {Code: "SZL", Digits: 2, Name: "Lilangeni"},
+	"THB": {Code: "THB", Digits: 2, Name: "Baht"},
+	"TJS": {Code: "TJS", Digits: 2, Name: "Somoni"},
+	"TMT": {Code: "TMT", Digits: 2, Name: "Turkmenistan New Manat"},
+	"TND": {Code: "TND", Digits: 3, Name: "Tunisian Dinar"},
+	"TOP": {Code: "TOP", Digits: 2, Name: "Pa'anga"},
+	"TRY": {Code: "TRY", Digits: 2, Name: "Turkish Lira"},
+	"TTD": {Code: "TTD", Digits: 2, Name: "Trinidad and Tobago Dollar"},
+	"TWD": {Code: "TWD", Digits: 2, Name: "New Taiwan Dollar"},
+	"TZS": {Code: "TZS", Digits: 2, Name: "Tanzanian Shilling"},
+	"UAH": {Code: "UAH", Digits: 2, Name: "Hryvnia"},
+	"UGX": {Code: "UGX", Digits: 0, Name: "Uganda Shilling"},
+	"USD": {Code: "USD", Digits: 2, Name: "US Dollar"},
+	"UYU": {Code: "UYU", Digits: 2, Name: "Peso Uruguayo"},
+	"UYW": {Code: "UYW", Digits: 4, Name: "Unidad Previsional"},
+	"UZS": {Code: "UZS", Digits: 2, Name: "Uzbekistan Sum"},
+	"VED": {Code: "VED", Digits: 2, Name: "Bolivar Soberano"},
+	"VES": {Code: "VES", Digits: 2, Name: "Bolivar Soberano"},
+	"VND": {Code: "VND", Digits: 0, Name: "Dong"},
+	"VUV": {Code: "VUV", Digits: 0, Name: "Vatu"},
+	"WST": {Code: "WST", Digits: 2, Name: "Tala"},
+	"XAF": {Code: "XAF", Digits: 0, Name: "CFA Franc BEAC"},
+	"XCD": {Code: "XCD", Digits: 2, Name: "East Caribbean Dollar"},
+	"XCG": {Code: "XCG", Digits: 2, Name: "Caribbean Guilder"},
+	"XOF": {Code: "XOF", Digits: 0, Name: "CFA Franc BCEAO"},
+	"XPF": {Code: "XPF", Digits: 0, Name: "CFP Franc"},
+	"YER": {Code: "YER", Digits: 2, Name: "Yemeni Rial"},
+	"ZAR": {Code: "ZAR", Digits: 2, Name: "Rand"},
+	"ZMW": {Code: "ZMW", Digits: 2, Name: "Zambian Kwacha"},
+	"ZWG": {Code: "ZWG", Digits: 2, Name: "Zimbabwe Gold"},
+}
+
+//LookupCurrency finds a currency by its code ignoring case and surrounding spaces
+func LookupCurrency(code string) (Currency, bool) {
+	currency, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	return currency, ok
+}
+
+//NormalizeCurrency returns the upper case ISO 4217 code or ErrCurrencyNotSupported
+func NormalizeCurrency(code string) (string, error) {
+	currency, ok := LookupCurrency(code)
+	if !ok {
+		return "", ErrCurrencyNotSupported
+	}
+	return currency.Code, nil
+}
@@ -0,0 +1,51 @@
+package money
+
+import (
+	"testing"
+)
+
+func TestNormalizeCurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    string
+		wantErr bool
+	}{
+		{name: "should_keep_upper_case_code", code: "COP", want: "COP"},
+		{name: "should_normalize_case_and_spaces", code: " usd ", want: "USD"},
+		{name: "should_fail_because_code_is_unknown", code: "ABC", wantErr: true},
+		{name: "should_fail_because_code_is_empty", code: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeCurrency(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NormalizeCurrency() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeCurrency() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigits(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		want     int32
+	}{
+		{name: "should_return_two_decimals_for_peso", currency: "COP", want: 2},
+		{name: "should_return_no_decimals_for_yen", currency: "jpy", want: 0},
+		{name: "should_return_three_decimals_for_dinar", currency: "KWD", want: 3},
+		{name: "should_return_two_decimals_for_unknown_code", currency: "ABC", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Digits(tt.currency); got != tt.want {
+				t.Errorf("Digits() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package money
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+)
+
+//maxScale max number of decimals a Decimal can keep
+const maxScale = 18
+
+//ErrOverflow custom error to represent a value that does not fit in an int64
+var ErrOverflow = errors.Validation("amount_overflow")
+
+//decimalPattern numbers accepted by ParseDecimal, same syntax as json and dynamodb N values
+var decimalPattern = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][+-]?\d+)?$`)
+
+//Decimal exact base 10 number, its value is units * 10^-scale
+type Decimal struct {
+	units int64
+	scale int32
+}
+
+//NewDecimal builds the decimal units * 10^-scale, for example NewDecimal(25, 1) is 2.5
+func NewDecimal(units int64, scale int32) Decimal {
+	for scale < 0 {
+		units *= 10
+		scale++
+	}
+	for scale > 0 && units%10 == 0 {
+		units /= 10
+		scale--
+	}
+	return Decimal{units: units, scale: scale}
+}
+
+//ParseDecimal reads a decimal without losing precision, like "2400", "2.5" or "1e-3"
+func ParseDecimal(value string) (Decimal, error) {
+	value = strings.TrimSpace(value)
+	if !decimalPattern.MatchString(value) {
+		return Decimal{}, fmt.Errorf("%q is not a decimal number", value)
+	}
+
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%q is not a decimal number", value)
+	}
+
+	return decimalFromRat(rat)
+}
+
+//decimalFromRat converts an exact rational with a finite decimal expansion
+func decimalFromRat(rat *big.Rat) (Decimal, error) {
+	scaled := new(big.Rat).Set(rat)
+	ten := big.NewRat(10, 1)
+	for scale := int32(0); scale <= maxScale; scale++ {
+		if scaled.IsInt() {
+			if !scaled.Num().IsInt64() {
+				return Decimal{}, ErrOverflow
+			}
+			return NewDecimal(scaled.Num().Int64(), scale), nil
+		}
+		scaled.Mul(scaled, ten)
+	}
+	return Decimal{}, fmt.Errorf("%v has more than %v decimals", rat.FloatString(maxScale+1), maxScale)
+}
+
+//Scale number of decimals of d
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+//Sign returns -1, 0 or 1 depending on the sign of d
+func (d Decimal) Sign() int {
+	switch {
+	case d.units < 0:
+		return -1
+	case d.units > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+//Cmp compares d and other returning -1, 0 or 1
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+//Equal indicates if d and other represent the same number
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+//Rat exact value of d as a rational
+func (d Decimal) Rat() *big.Rat {
+	denominator := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(big.NewInt(d.units), denominator)
+}
+
+//String formats d without exponent and without trailing zeros
+func (d Decimal) String() string {
+	if d.scale == 0 {
+		return strconv.FormatInt(d.units, 10)
+	}
+
+	sign := ""
+	digits := strconv.FormatInt(d.units, 10)
+	if d.units < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= int(d.scale) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+//MarshalJSON writes d as a json number
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//UnmarshalJSON reads a json number keeping every decimal
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	value, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+
+	*d = value
+	return nil
+}
@@ -0,0 +1,111 @@
+package money
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Decimal
+		wantErr bool
+	}{
+		{
+			name:  "should_parse_integer",
+			value: "2400",
+			want:  NewDecimal(2400, 0),
+		},
+		{
+			name:  "should_parse_decimals_without_trailing_zeros",
+			value: "2.50",
+			want:  NewDecimal(25, 1),
+		},
+		{
+			name:  "should_parse_more_than_six_decimals",
+			value: "0.00025974",
+			want:  NewDecimal(25974, 8),
+		},
+		{
+			name:  "should_parse_exponent",
+			value: "1.5e3",
+			want:  NewDecimal(1500, 0),
+		},
+		{
+			name:  "should_parse_negative",
+			value: "-0.5",
+			want:  NewDecimal(-5, 1),
+		},
+		{
+			name:    "should_fail_because_value_is_a_fraction",
+			value:   "1/3",
+			wantErr: true,
+		},
+		{
+			name:    "should_fail_because_value_is_not_a_number",
+			value:   "COP",
+			wantErr: true,
+		},
+		{
+			name:    "should_fail_because_value_overflows",
+			value:   "92233720368547758080",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDecimal(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDecimal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDecimal() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		decimal Decimal
+		want    string
+	}{
+		{name: "should_format_integer", decimal: NewDecimal(2400, 0), want: "2400"},
+		{name: "should_format_decimals", decimal: NewDecimal(25, 1), want: "2.5"},
+		{name: "should_format_leading_zeros", decimal: NewDecimal(25, 4), want: "0.0025"},
+		{name: "should_format_negative", decimal: NewDecimal(-5, 2), want: "-0.05"},
+		{name: "should_drop_trailing_zeros", decimal: NewDecimal(150, 2), want: "1.5"},
+		{name: "should_format_zero", decimal: Decimal{}, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.decimal.String(); got != tt.want {
+				t.Errorf("String() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	type priced struct {
+		Price Decimal `json:"price"`
+	}
+	var got priced
+	err := json.Unmarshal([]byte(`{"price":0.1000000000000001}`), &got)
+	if err != nil {
+		t.Fatalf("error unmarshalling decimal %v", err)
+	}
+
+	body, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("error marshalling decimal %v", err)
+	}
+
+	if string(body) != `{"price":0.1000000000000001}` {
+		t.Errorf("json round trip lost precision, got %v", string(body))
+	}
+}
@@ -0,0 +1,144 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+)
+
+//RoundingMode strategy to drop the digits a currency can not represent
+type RoundingMode int
+
+const (
+	//HalfEven rounds to the nearest value and ties to the even one, also known as bankers rounding
+	HalfEven RoundingMode = iota
+	//HalfUp rounds to the nearest value and ties away from zero
+	HalfUp
+	//Down truncates the extra digits, rounding towards zero
+	Down
+	//Up rounds away from zero whenever there are extra digits
+	Up
+)
+
+//ErrPrecision custom error to represent an amount with more decimals than its currency allows
+var ErrPrecision = errors.Validation("amount_exceeds_currency_precision")
+
+//Money amount in minor units of an ISO 4217 currency, {Amount: 250, Currency: "USD"} is 2.50 USD
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+//Digits number of minor unit decimals used by currency, two when currency is not in the registry
+func Digits(currency string) int32 {
+	if value, ok := LookupCurrency(currency); ok {
+		return value.Digits
+	}
+	return 2
+}
+
+//New builds money from a decimal that must fit exactly in the minor units of currency
+func New(amount Decimal, currency string) (Money, error) {
+	digits := Digits(currency)
+	if amount.scale > digits {
+		return Money{}, ErrPrecision
+	}
+
+	units := amount.units
+	for scale := amount.scale; scale < digits; scale++ {
+		if units > math.MaxInt64/10 || units < -math.MaxInt64/10 {
+			return Money{}, ErrOverflow
+		}
+		units *= 10
+	}
+
+	return Money{Amount: units, Currency: currency}, nil
+}
+
+//FromRat builds money from an exact rational rounding it to the minor units of currency
+func FromRat(amount *big.Rat, currency string, mode RoundingMode) (Money, error) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Digits(currency))), nil)
+	scaled := new(big.Rat).Mul(amount, new(big.Rat).SetInt(scale))
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if remainder.Sign() != 0 && roundAway(quotient, remainder, scaled.Denom(), mode) {
+		quotient.Add(quotient, big.NewInt(int64(scaled.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+
+	return Money{Amount: quotient.Int64(), Currency: currency}, nil
+}
+
+//roundAway indicates if a truncated quotient must move one unit away from zero
+func roundAway(quotient, remainder, denominator *big.Int, mode RoundingMode) bool {
+	switch mode {
+	case Down:
+		return false
+	case Up:
+		return true
+	}
+
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+	switch twice.Cmp(denominator) {
+	case 1:
+		return true
+	case -1:
+		return false
+	}
+
+	if mode == HalfUp {
+		return true
+	}
+	return quotient.Bit(0) == 1
+}
+
+//Decimal value of m in major units
+func (m Money) Decimal() Decimal {
+	return NewDecimal(m.Amount, Digits(m.Currency))
+}
+
+//Rat exact value of m in major units
+func (m Money) Rat() *big.Rat {
+	return m.Decimal().Rat()
+}
+
+//Mul multiplies m by a quantity, the result is exact or ErrOverflow when it does not fit in minor units
+func (m Money) Mul(quantity int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(quantity))
+	if !product.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: product.Int64(), Currency: m.Currency}, nil
+}
+
+//String formats m like "2.50 USD"
+func (m Money) String() string {
+	digits := Digits(m.Currency)
+	value := m.Decimal().String()
+	if digits > 0 {
+		point := strings.IndexByte(value, '.')
+		if point < 0 {
+			value += "."
+			point = len(value) - 1
+		}
+		value += strings.Repeat("0", int(digits)-(len(value)-point-1))
+	}
+	return value + " " + m.Currency
+}
+
+//RateFromFloat exact rational of the shortest decimal that represents rate, so 0.1 is 1/10
+func RateFromFloat(rate float64) (*big.Rat, error) {
+	value, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'g', -1, 64))
+	if !ok {
+		return nil, fmt.Errorf("%v is not a valid rate", rate)
+	}
+	return value, nil
+}
@@ -0,0 +1,116 @@
+package money
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Decimal
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{
+			name:     "should_build_money_in_cents",
+			amount:   NewDecimal(25, 1),
+			currency: "USD",
+			want:     Money{Amount: 250, Currency: "USD"},
+		},
+		{
+			name:     "should_build_money_without_minor_units",
+			amount:   NewDecimal(500, 0),
+			currency: "JPY",
+			want:     Money{Amount: 500, Currency: "JPY"},
+		},
+		{
+			name:     "should_fail_because_amount_has_too_many_decimals",
+			amount:   NewDecimal(2555, 3),
+			currency: "USD",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.amount, tt.currency)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromRat(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount *big.Rat
+		mode   RoundingMode
+		want   int64
+	}{
+		{name: "should_round_half_even_down", amount: big.NewRat(1025, 1000), mode: HalfEven, want: 102},
+		{name: "should_round_half_even_up", amount: big.NewRat(1035, 1000), mode: HalfEven, want: 104},
+		{name: "should_round_half_up", amount: big.NewRat(1025, 1000), mode: HalfUp, want: 103},
+		{name: "should_round_down", amount: big.NewRat(1029, 1000), mode: Down, want: 102},
+		{name: "should_round_up", amount: big.NewRat(1021, 1000), mode: Up, want: 103},
+		{name: "should_round_negative_away_from_zero", amount: big.NewRat(-1025, 1000), mode: HalfUp, want: -103},
+		{name: "should_round_to_nearest", amount: big.NewRat(2, 3), mode: HalfEven, want: 67},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromRat(tt.amount, "USD", tt.mode)
+			if err != nil {
+				t.Errorf("FromRat() error = %v", err)
+				return
+			}
+			if got.Amount != tt.want {
+				t.Errorf("FromRat() got = %v, want %v", got.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_BoxDoesNotDrift(t *testing.T) {
+	price, err := New(NewDecimal(1, 1), "USD")
+	if err != nil {
+		t.Fatalf("error building money %v", err)
+	}
+
+	rate, err := RateFromFloat(4000.1)
+	if err != nil {
+		t.Fatalf("error reading rate %v", err)
+	}
+
+	unit, err := FromRat(new(big.Rat).Mul(price.Rat(), rate), "COP", HalfEven)
+	if err != nil {
+		t.Fatalf("error converting money %v", err)
+	}
+
+	box, err := unit.Mul(24)
+	if err != nil {
+		t.Fatalf("error multiplying money %v", err)
+	}
+	if got := box.String(); got != "9600.24 COP" {
+		t.Errorf("box of 24 got = %v, want 9600.24 COP", got)
+	}
+}
+
+func TestMoney_Mul(t *testing.T) {
+	got, err := Money{Amount: 240000, Currency: "COP"}.Mul(-3)
+	if err != nil || got != (Money{Amount: -720000, Currency: "COP"}) {
+		t.Errorf("Mul() got = %v, %v, want -7200.00 COP", got, err)
+	}
+
+	_, err = Money{Amount: 240000, Currency: "COP"}.Mul(9223372036854775)
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Mul() out of int64 must return ErrOverflow but return %v", err)
+	}
+}
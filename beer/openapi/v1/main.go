@@ -0,0 +1,12 @@
+package main
+
+import (
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/middleware"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/openapi"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	lambda.Start(middleware.Chain(openapi.Handler, middleware.Standard(logrus.StandardLogger())...))
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+)
+
+const (
+	//batchGetSize max keys dynamodb accepts in one BatchGetItem
+	batchGetSize = 100
+	//batchWriteSize max puts SaveBatch sends at the same time
+	batchWriteSize = 25
+	//batchRetries times unprocessed keys or items are sent again
+	batchRetries = 5
+	//batchBackoff wait before the first retry, it doubles on every retry
+	batchBackoff = 50 * time.Millisecond
+)
+
+//FindMany method to search several beers at once, the result only has the beers that exist
+func (b *BeerRepository) FindMany(ctx context.Context, IDs []int) (map[int]model.Beer, error) {
+	logger := lib.Logger(ctx, b.logger).WithField("beerIDs", IDs)
+	logger.Info("finding beers")
+
+	found := make(map[int]model.Beer, len(IDs))
+	for start := 0; start < len(IDs); start += batchGetSize {
+		end := start + batchGetSize
+		if end > len(IDs) {
+			end = len(IDs)
+		}
+
+		keys := make([]map[string]*dynamodb.AttributeValue, 0, end-start)
+		for _, ID := range IDs[start:end] {
+			keys = append(keys, map[string]*dynamodb.AttributeValue{
+				"id": {
+					S: aws.String(strconv.Itoa(ID)),
+				},
+			})
+		}
+
+		request := map[string]*dynamodb.KeysAndAttributes{
+			b.tableBeers: {
+				Keys: keys,
+			},
+		}
+		backoff := batchBackoff
+		for attempt := 0; len(request) > 0; attempt++ {
+			if attempt > batchRetries {
+				logger.Error("keys left unprocessed after retries")
+				return nil, ErrBatchIncomplete
+			}
+			if attempt > 0 {
+				if err := wait(ctx, backoff); err != nil {
+					logger.WithError(err).Error("no time left to retry unprocessed keys")
+					return nil, err
+				}
+				backoff *= 2
+			}
+
+			out, err := b.client.BatchGetItemWithContext(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: request,
+			})
+			if err != nil {
+				logger.WithError(err).Error("error finding beers")
+				return nil, errors.FromContext(ctx, err)
+			}
+
+			beers, err := b.hydrate(out.Responses[b.tableBeers])
+			if err != nil {
+				return nil, err
+			}
+			for _, beer := range beers {
+				found[beer.ID] = beer
+			}
+			request = out.UnprocessedKeys
+		}
+	}
+	return found, nil
+}
+
+//SaveBatch method to save new beers with the conditional put of Save, batchWriteSize of them at the same
+//time, so a beer that already exists is neither replaced nor brought back when it was soft deleted. It
+//returns the IDs that were taken and the ones that could not be saved, err is the first error of those
+func (b *BeerRepository) SaveBatch(ctx context.Context, beers []model.Beer) ([]int, []int, error) {
+	lib.Logger(ctx, b.logger).WithField("beers", len(beers)).Info("saving beers")
+
+	errs := make([]error, len(beers))
+	slots := make(chan struct{}, batchWriteSize)
+	var wg sync.WaitGroup
+	for i, beer := range beers {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, beer model.Beer) {
+			defer wg.Done()
+			errs[i] = b.Save(ctx, beer)
+			<-slots
+		}(i, beer)
+	}
+	wg.Wait()
+
+	conflicts, unprocessed, err := batchOutcome(beers, errs)
+	if err != nil {
+		lib.Logger(ctx, b.logger).WithError(err).WithField("left", len(unprocessed)).Error("error saving beers")
+	}
+	return conflicts, unprocessed, err
+}
+
+//wait sleeps d unless ctx is done first, then it returns the timeout error of ctx
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return errors.FromContext(ctx, ctx.Err())
+	}
+}
+
+//batchOutcome splits the beers of a batch by the error of their Save, the taken IDs go in conflicts
+//and the ones that failed otherwise in unprocessed with the first of those errors
+func batchOutcome(beers []model.Beer, errs []error) ([]int, []int, error) {
+	var (
+		conflicts   []int
+		unprocessed []int
+		first       error
+	)
+	for i, err := range errs {
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrAlreadyExists):
+			conflicts = append(conflicts, beers[i].ID)
+		default:
+			unprocessed = append(unprocessed, beers[i].ID)
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	return conflicts, unprocessed, first
+}
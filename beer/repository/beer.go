@@ -1,15 +1,36 @@
 package repository
 
 import (
-	"fmt"
+	"context"
 	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
 	"github.com/sirupsen/logrus"
 )
 
+//ErrVersionConflict custom error to represent that the beer changed since the expected version
+var ErrVersionConflict = errors.PreconditionFailed("error_beer_version_conflict")
+
+//ErrBatchIncomplete custom error to represent a batch dynamodb kept leaving unprocessed
+var ErrBatchIncomplete = errors.Upstream("error_batch_incomplete", nil)
+
+//ErrAlreadyExists custom error to represent that there is already a beer with the given ID
+var ErrAlreadyExists = errors.Conflict("error_beer_already_created")
+
+//ErrBeerNotFound custom error to represent that there is no beer with the given ID
+var ErrBeerNotFound = errors.NotFound("beerID_does_not_exist")
+
+//counterID key of the item holding the last ID given by NextID, it has no active attribute
+//so it is never read by the listings
+const counterID = "__counter"
+
 //BeerRepository main struct for repository
 type BeerRepository struct {
 	client     *dynamodb.DynamoDB
@@ -18,8 +39,8 @@ type BeerRepository struct {
 }
 
 //Find method to search a beer
-func (b *BeerRepository) Find(ID int) (model.Beer, error) {
-	out, err := b.client.Query(&dynamodb.QueryInput{
+func (b *BeerRepository) Find(ctx context.Context, ID int) (model.Beer, error) {
+	out, err := b.client.QueryWithContext(ctx, &dynamodb.QueryInput{
 		TableName: aws.String(b.tableBeers),
 		KeyConditions: map[string]*dynamodb.Condition{
 			"id": {
@@ -33,7 +54,7 @@ func (b *BeerRepository) Find(ID int) (model.Beer, error) {
 		},
 	})
 	if err != nil {
-		return model.Beer{}, err
+		return model.Beer{}, errors.FromContext(ctx, err)
 	}
 
 	if len(out.Items) == 0 {
@@ -47,43 +68,234 @@ func (b *BeerRepository) Find(ID int) (model.Beer, error) {
 	return beers[0], nil
 }
 
-//Save method to save a beer
-func (b *BeerRepository) Save(beer model.Beer) error {
-	logger := b.logger.WithField("model", beer)
+//Save method to save a new beer, it returns ErrAlreadyExists when the ID is taken
+func (b *BeerRepository) Save(ctx context.Context, beer model.Beer) error {
+	logger := lib.Logger(ctx, b.logger).WithField("model", beer)
 	logger.Info("saving beer")
 	item := &dynamodb.PutItemInput{
-		Item: map[string]*dynamodb.AttributeValue{
+		Item:                newBeerItem(beer),
+		TableName:           aws.String(b.tableBeers),
+		ConditionExpression: aws.String("attribute_not_exists(#id)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#id": aws.String("id"),
+		},
+	}
+	_, err := b.client.PutItemWithContext(ctx, item)
+	if isConditionalCheckFailed(err) {
+		logger.Info("beer already exists")
+		return ErrAlreadyExists
+	}
+
+	return errors.FromContext(ctx, err)
+}
+
+//NextID method to allocate a beer ID incrementing atomically the counter item, IDs are never
+//given twice but they may be taken already by beers created with an ID chosen by the client
+func (b *BeerRepository) NextID(ctx context.Context) (int, error) {
+	out, err := b.client.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(b.tableBeers),
+		Key: map[string]*dynamodb.AttributeValue{
 			"id": {
-				S: aws.String(strconv.Itoa(beer.ID)),
-			},
-			"name": {
-				S: aws.String(beer.Name),
-			},
-			"brewery": {
-				S: aws.String(beer.Brewery),
+				S: aws.String(counterID),
 			},
-			"country": {
-				S: aws.String(beer.Country),
-			},
-			"price": {
-				N: aws.String(fmt.Sprintf("%f", beer.Price)),
+		},
+		UpdateExpression: aws.String("ADD #last_id :one"),
+		ExpressionAttributeNames: map[string]*string{
+			"#last_id": aws.String("last_id"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":one": {
+				N: aws.String("1"),
 			},
-			"currency": {
-				S: aws.String(beer.Currency),
+		},
+		ReturnValues: aws.String(dynamodb.ReturnValueUpdatedNew),
+	})
+	if err != nil {
+		lib.Logger(ctx, b.logger).WithError(err).Error("error allocating beer ID")
+		return 0, errors.FromContext(ctx, err)
+	}
+
+	return strconv.Atoi(*out.Attributes["last_id"].N)
+}
+
+//newBeerItem attributes of a beer saved for the first time
+func newBeerItem(beer model.Beer) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"id": {
+			S: aws.String(strconv.Itoa(beer.ID)),
+		},
+		"name": {
+			S: aws.String(beer.Name),
+		},
+		"brewery": {
+			S: aws.String(beer.Brewery),
+		},
+		"country": {
+			S: aws.String(beer.Country),
+		},
+		"price": {
+			N: aws.String(beer.Price.String()),
+		},
+		"currency": {
+			S: aws.String(beer.Currency),
+		},
+		"active": {
+			N: aws.String("1"),
+		},
+		"version": {
+			N: aws.String("1"),
+		},
+	}
+}
+
+//Update method to replace the attributes of a beer only if it is still in the expected version,
+//version 0 matches beers saved before versioning existed
+func (b *BeerRepository) Update(ctx context.Context, beer model.Beer, version int) (model.Beer, error) {
+	logger := lib.Logger(ctx, b.logger).WithField("model", beer).WithField("version", version)
+	logger.Info("updating beer")
+
+	condition := "attribute_exists(#id) AND #version = :version"
+	values := map[string]*dynamodb.AttributeValue{
+		":name": {
+			S: aws.String(beer.Name),
+		},
+		":brewery": {
+			S: aws.String(beer.Brewery),
+		},
+		":country": {
+			S: aws.String(beer.Country),
+		},
+		":price": {
+			N: aws.String(beer.Price.String()),
+		},
+		":currency": {
+			S: aws.String(beer.Currency),
+		},
+		":next": {
+			N: aws.String(strconv.Itoa(version + 1)),
+		},
+	}
+	if version == 0 {
+		condition = "attribute_exists(#id) AND attribute_not_exists(#version)"
+	} else {
+		values[":version"] = &dynamodb.AttributeValue{
+			N: aws.String(strconv.Itoa(version)),
+		}
+	}
+
+	out, err := b.client.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(b.tableBeers),
+		Key: map[string]*dynamodb.AttributeValue{
+			"id": {
+				S: aws.String(strconv.Itoa(beer.ID)),
 			},
-			"active": {
-				N: aws.String("1"),
+		},
+		UpdateExpression: aws.String(
+			"SET #name = :name, #brewery = :brewery, #country = :country, " +
+				"#price = :price, #currency = :currency, #version = :next",
+		),
+		ConditionExpression: aws.String(condition),
+		ExpressionAttributeNames: map[string]*string{
+			"#id":       aws.String("id"),
+			"#name":     aws.String("name"),
+			"#brewery":  aws.String("brewery"),
+			"#country":  aws.String("country"),
+			"#price":    aws.String("price"),
+			"#currency": aws.String("currency"),
+			"#version":  aws.String("version"),
+		},
+		ExpressionAttributeValues: values,
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+	})
+	if isConditionalCheckFailed(err) {
+		logger.Info("beer changed since expected version")
+		return model.Beer{}, ErrVersionConflict
+	}
+	if err != nil {
+		logger.WithError(err).Error("error updating beer")
+		return model.Beer{}, errors.FromContext(ctx, err)
+	}
+
+	beers, err := b.hydrate([]map[string]*dynamodb.AttributeValue{out.Attributes})
+	if err != nil {
+		return model.Beer{}, err
+	}
+	return beers[0], nil
+}
+
+//SoftDelete method to take a beer out of the listings keeping its data, the version is bumped
+//so clients holding an ETag of the active beer get a conflict
+func (b *BeerRepository) SoftDelete(ctx context.Context, ID int) (model.Beer, error) {
+	deletedAt := time.Now().UTC().Format(time.RFC3339)
+	return b.setActive(ctx, ID, "SET #active = :active, #deleted_at = :deleted_at ADD #version :one", map[string]*dynamodb.AttributeValue{
+		":active": {
+			N: aws.String("0"),
+		},
+		":deleted_at": {
+			S: aws.String(deletedAt),
+		},
+	})
+}
+
+//Restore method to list again a soft deleted beer
+func (b *BeerRepository) Restore(ctx context.Context, ID int) (model.Beer, error) {
+	return b.setActive(ctx, ID, "SET #active = :active REMOVE #deleted_at ADD #version :one", map[string]*dynamodb.AttributeValue{
+		":active": {
+			N: aws.String("1"),
+		},
+	})
+}
+
+//setActive method to apply an update expression over the active flag of an existing beer
+func (b *BeerRepository) setActive(
+	ctx context.Context,
+	ID int,
+	expression string,
+	values map[string]*dynamodb.AttributeValue,
+) (model.Beer, error) {
+	logger := lib.Logger(ctx, b.logger).WithField("beerID", ID).WithField("expression", expression)
+	logger.Info("changing active flag of beer")
+
+	values[":one"] = &dynamodb.AttributeValue{
+		N: aws.String("1"),
+	}
+	out, err := b.client.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(b.tableBeers),
+		Key: map[string]*dynamodb.AttributeValue{
+			"id": {
+				S: aws.String(strconv.Itoa(ID)),
 			},
 		},
-		TableName:           aws.String(b.tableBeers),
-		ConditionExpression: aws.String("attribute_not_exists(#id)"),
+		UpdateExpression:    aws.String(expression),
+		ConditionExpression: aws.String("attribute_exists(#id)"),
 		ExpressionAttributeNames: map[string]*string{
-			"#id": aws.String("id"),
+			"#id":         aws.String("id"),
+			"#active":     aws.String("active"),
+			"#deleted_at": aws.String("deleted_at"),
+			"#version":    aws.String("version"),
 		},
+		ExpressionAttributeValues: values,
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+	})
+	if isConditionalCheckFailed(err) {
+		return model.Beer{}, ErrBeerNotFound
 	}
-	_, err := b.client.PutItem(item)
+	if err != nil {
+		logger.WithError(err).Error("error changing active flag of beer")
+		return model.Beer{}, errors.FromContext(ctx, err)
+	}
+
+	beers, err := b.hydrate([]map[string]*dynamodb.AttributeValue{out.Attributes})
+	if err != nil {
+		return model.Beer{}, err
+	}
+	return beers[0], nil
+}
 
-	return err
+//isConditionalCheckFailed indicates if dynamodb rejected a write because of its condition expression
+func isConditionalCheckFailed(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
 }
 
 //hydrate method to populate []model.Beer from dynamodb.AttributeValue
@@ -110,7 +322,7 @@ func (b *BeerRepository) hydrate(items []map[string]*dynamodb.AttributeValue) ([
 		}
 
 		if v, ok := item["price"]; ok {
-			price, err := strconv.ParseFloat(*v.N, 64)
+			price, err := money.ParseDecimal(*v.N)
 			if err != nil {
 				return []model.Beer{}, err
 			}
@@ -120,64 +332,185 @@ func (b *BeerRepository) hydrate(items []map[string]*dynamodb.AttributeValue) ([
 		if v, ok := item["currency"]; ok {
 			beers[i].Currency = *v.S
 		}
+
+		if v, ok := item["version"]; ok {
+			version, err := strconv.Atoi(*v.N)
+			if err != nil {
+				return []model.Beer{}, err
+			}
+			beers[i].Version = version
+		}
+
+		if v, ok := item["active"]; ok && v.N != nil && *v.N == "0" {
+			deletedAt := time.Time{}
+			if d, ok := item["deleted_at"]; ok && d.S != nil {
+				deletedAt, err = time.Parse(time.RFC3339, *d.S)
+				if err != nil {
+					return []model.Beer{}, err
+				}
+			}
+			beers[i].DeletedAt = &deletedAt
+		}
 	}
 	return beers, nil
 }
 
 //List method to list all beers in database
-func (b *BeerRepository) List() ([]model.Beer, error) {
-	logger := b.logger
+func (b *BeerRepository) List(ctx context.Context) ([]model.Beer, error) {
+	return b.ListFiltered(ctx, ListFilter{})
+}
+
+//ListFiltered method to list all active beers matching the filter
+func (b *BeerRepository) ListFiltered(ctx context.Context, filter ListFilter) ([]model.Beer, error) {
+	logger := lib.Logger(ctx, b.logger).WithField("filter", filter)
 	logger.Info("beginning of list beers")
-	out, err := b.client.Query(&dynamodb.QueryInput{
-		TableName:              aws.String(b.tableBeers),
-		IndexName:              aws.String("by_active"),
-		KeyConditionExpression: aws.String("active = :active"),
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":active": {
-				N: aws.String("1"),
-			},
-		},
-	})
+
+	beers := []model.Beer{}
+	var lastKey map[string]string
+	for {
+		page, next, err := b.ListPage(ctx, filter, 0, lastKey)
+		if err != nil {
+			logger.WithError(err).Error("an error occurred reading another page")
+			return []model.Beer{}, err
+		}
+		beers = append(beers, page...)
+		if len(next) == 0 {
+			break
+		}
+		lastKey = next
+	}
+
+	if len(beers) == 0 {
+		logger.Info("no beers found")
+	}
+	return beers, nil
+}
+
+//ListPage method to list one page of active beers matching the filter starting after startKey, limit 0
+//lets dynamodb fill the page up to 1 MB. The by_country and by_brewery indexes are queried when the
+//filter has a country or a brewery. The returned key is empty when there are no more pages
+func (b *BeerRepository) ListPage(
+	ctx context.Context,
+	filter ListFilter,
+	limit int,
+	startKey map[string]string,
+) ([]model.Beer, map[string]string, error) {
+	logger := lib.Logger(ctx, b.logger).WithField("filter", filter).WithField("limit", limit).WithField("start_key", startKey)
+	logger.Info("listing page of beers")
+
+	input := filter.query(b.tableBeers)
+	if limit > 0 {
+		input.Limit = aws.Int64(int64(limit))
+	}
+	if len(startKey) > 0 {
+		if !filter.continues(startKey) {
+			logger.Error("start key belongs to another query")
+			return []model.Beer{}, nil, lib.ErrCursorNotValid
+		}
+		input.ExclusiveStartKey = toAttributeValues(startKey)
+	}
+
+	out, err := b.client.QueryWithContext(ctx, input)
 	if err != nil {
 		logger.WithError(err).Error("error listing beers")
-		return []model.Beer{}, err
+		return []model.Beer{}, nil, errors.FromContext(ctx, err)
 	}
-	if len(out.Items) == 0 {
-		logger.Info("no beers found")
-		return []model.Beer{}, nil
+
+	beers, err := b.hydrate(out.Items)
+	if err != nil {
+		logger.WithError(err).Error("error reading page of beers")
+		return []model.Beer{}, nil, err
 	}
+	return beers, fromAttributeValues(out.LastEvaluatedKey), nil
+}
+
+//ListByBrewery method to list the active beers of a brewery using the by_brewery index
+func (b *BeerRepository) ListByBrewery(ctx context.Context, brewery string) ([]model.Beer, error) {
+	return b.listByIndex(ctx, "by_brewery", "brewery", brewery)
+}
 
-	tmp := out.Items
-	lastEvaluatedKey := out.LastEvaluatedKey
-	for len(lastEvaluatedKey) != 0 {
-		out, err = b.client.Query(&dynamodb.QueryInput{
+//ListByCountry method to list the active beers of a country using the by_country index
+func (b *BeerRepository) ListByCountry(ctx context.Context, country string) ([]model.Beer, error) {
+	return b.listByIndex(ctx, "by_country", "country", country)
+}
+
+//listByIndex method to read every page of an index whose hash key is a string attribute,
+//beers that were soft deleted are left out
+func (b *BeerRepository) listByIndex(ctx context.Context, index, attribute, value string) ([]model.Beer, error) {
+	logger := lib.Logger(ctx, b.logger).WithField("index", index).WithField(attribute, value)
+	logger.Info("beginning of list beers by index")
+
+	items := []map[string]*dynamodb.AttributeValue{}
+	var lastEvaluatedKey map[string]*dynamodb.AttributeValue
+	for {
+		out, err := b.client.QueryWithContext(ctx, &dynamodb.QueryInput{
 			TableName:              aws.String(b.tableBeers),
-			IndexName:              aws.String("by_active"),
-			KeyConditionExpression: aws.String("active = :active"),
+			IndexName:              aws.String(index),
+			KeyConditionExpression: aws.String("#key = :key"),
+			FilterExpression:       aws.String("#active = :active"),
+			ExpressionAttributeNames: map[string]*string{
+				"#key":    aws.String(attribute),
+				"#active": aws.String("active"),
+			},
 			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":key": {
+					S: aws.String(value),
+				},
 				":active": {
-					S: aws.String("1"),
+					N: aws.String("1"),
 				},
 			},
 			ExclusiveStartKey: lastEvaluatedKey,
 		})
-		lastEvaluatedKey = out.LastEvaluatedKey
 		if err != nil {
-			logger.WithError(err).Error("an error occurred reading another page")
-			return []model.Beer{}, err
+			logger.WithError(err).Error("error listing beers by index")
+			return []model.Beer{}, errors.FromContext(ctx, err)
 		}
-		tmp = append(tmp, out.Items...)
+		items = append(items, out.Items...)
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		lastEvaluatedKey = out.LastEvaluatedKey
 	}
 
-	beers, err := b.hydrate(tmp)
+	beers, err := b.hydrate(items)
 	if err != nil {
-		logger.WithError(err).Error("an error occurred reading another page")
+		logger.WithError(err).Error("error reading beers of index")
 		return []model.Beer{}, err
 	}
-
 	return beers, nil
 }
 
+//toAttributeValues converts a page key back to dynamodb attributes, active is the only numeric key attribute
+func toAttributeValues(key map[string]string) map[string]*dynamodb.AttributeValue {
+	values := make(map[string]*dynamodb.AttributeValue, len(key))
+	for name, value := range key {
+		if name == "active" {
+			values[name] = &dynamodb.AttributeValue{N: aws.String(value)}
+			continue
+		}
+		values[name] = &dynamodb.AttributeValue{S: aws.String(value)}
+	}
+	return values
+}
+
+//fromAttributeValues converts the last evaluated key of dynamodb to a page key
+func fromAttributeValues(values map[string]*dynamodb.AttributeValue) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	key := make(map[string]string, len(values))
+	for name, value := range values {
+		switch {
+		case value.N != nil:
+			key[name] = *value.N
+		case value.S != nil:
+			key[name] = *value.S
+		}
+	}
+	return key
+}
+
 //NewBeerRepository construct for repository
 func NewBeerRepository(
 	client *dynamodb.DynamoDB,
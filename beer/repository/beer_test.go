@@ -1,9 +1,9 @@
 package repository
 
 import (
-	"errors"
+	"context"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
 	"github.com/chandy20/prueba-smartjobandina/beer/model"
-	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	"log"
 	"net"
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/ory/dockertest"
@@ -31,6 +32,14 @@ func createBeersTable(client *dynamodb.DynamoDB, table string, t *testing.T) {
 				AttributeName: aws.String("active"),
 				AttributeType: aws.String("N"),
 			},
+			{
+				AttributeName: aws.String("brewery"),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String("country"),
+				AttributeType: aws.String("S"),
+			},
 		},
 		KeySchema: []*dynamodb.KeySchemaElement{
 			{
@@ -60,6 +69,38 @@ func createBeersTable(client *dynamodb.DynamoDB, table string, t *testing.T) {
 					WriteCapacityUnits: aws.Int64(5),
 				},
 			},
+			{
+				IndexName: aws.String("by_brewery"),
+				KeySchema: []*dynamodb.KeySchemaElement{
+					{
+						AttributeName: aws.String("brewery"),
+						KeyType:       aws.String("HASH"),
+					},
+				},
+				Projection: &dynamodb.Projection{
+					ProjectionType: aws.String("ALL"),
+				},
+				ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(5),
+					WriteCapacityUnits: aws.Int64(5),
+				},
+			},
+			{
+				IndexName: aws.String("by_country"),
+				KeySchema: []*dynamodb.KeySchemaElement{
+					{
+						AttributeName: aws.String("country"),
+						KeyType:       aws.String("HASH"),
+					},
+				},
+				Projection: &dynamodb.Projection{
+					ProjectionType: aws.String("ALL"),
+				},
+				ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(5),
+					WriteCapacityUnits: aws.Int64(5),
+				},
+			},
 		},
 	})
 	if err != nil {
@@ -67,123 +108,47 @@ func createBeersTable(client *dynamodb.DynamoDB, table string, t *testing.T) {
 	}
 }
 
-func TestBeerRepository_SaveAndFind(t *testing.T) {
-	tableBeers := "table_warehouses" + postfix()
+//TestBeerRepository_Conformance runs the conformance suite against dynamodb, every scenario gets its own table
+func TestBeerRepository_Conformance(t *testing.T) {
 	closer, client := dynamodbServerStart(t)
 	defer closer()
-	createBeersTable(client, tableBeers, t)
-
-	beerToSave := model.Beer{
-		ID:       1,
-		Name:     "Pilsen",
-		Brewery:  "Bavaria",
-		Country:  "Colombia",
-		Price:    2400,
-		Currency: "COP",
-	}
-
-	beerRepository := NewBeerRepository(client, tableBeers, logrus.New())
-
-	err := beerRepository.Save(beerToSave)
-	if err != nil {
-		t.Errorf("error saving berr %v", err)
-	}
-
-	beerGot, err := beerRepository.Find(beerToSave.ID)
-	if err != nil {
-		t.Errorf("error finding beer %v", err)
-	}
 
-	if diff := cmp.Diff(beerToSave, beerGot); diff != "" {
-		t.Errorf("Error, saved beer is different than expected, (-want,+got)\n%s", diff)
-	}
+	testBeers(t, func(t *testing.T) Beers {
+		tableBeers := "table_warehouses" + postfix()
+		createBeersTable(client, tableBeers, t)
+		return NewBeerRepository(client, tableBeers, logrus.New())
+	})
+}
 
-	beerGot, err = beerRepository.Find(2)
+func TestBeerRepository_ExpiredContext(t *testing.T) {
+	session, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("x", "x", ""),
+	})
 	if err != nil {
-		t.Errorf("error finding beer %v", err)
-	}
-
-	if beerGot.ID > 0 {
-		t.Errorf("the test musn't return any beer but return %v", beerGot)
+		t.Fatalf("error creating session %v", err)
 	}
+	client := dynamodb.New(session, &aws.Config{Endpoint: aws.String("http://127.0.0.1:1")})
+	beerRepository := NewBeerRepository(client, "table_warehouses"+postfix(), logrus.New())
 
-}
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
 
-func TestBeerRepository_SaveAndList(t *testing.T) {
-	tableBeers := "table_warehouses" + postfix()
-	closer, client := dynamodbServerStart(t)
-	defer closer()
-	createBeersTable(client, tableBeers, t)
-
-	beersToSave := []model.Beer{
-		{
-			ID:       1,
-			Name:     "Pilsen",
-			Brewery:  "Bavaria",
-			Country:  "Colombia",
-			Price:    2400,
-			Currency: "COP",
-		},
-		{
-			ID:       2,
-			Name:     "Brava",
-			Brewery:  "Bavaria",
-			Country:  "Colombia",
-			Price:    2000,
-			Currency: "COP",
-		},
-		{
-			ID:       3,
-			Name:     "Corona",
-			Brewery:  "Bavaria",
-			Country:  "Mexico",
-			Price:    200,
-			Currency: "MXN",
-		},
-		{
-			ID:       4,
-			Name:     "Budweiser",
-			Brewery:  "Bavaria",
-			Country:  "Colombia",
-			Price:    2.5,
-			Currency: "USD",
-		},
-		{
-			ID:       5,
-			Name:     "Leona",
-			Brewery:  "Bavaria",
-			Country:  "Colombia",
-			Price:    2000,
-			Currency: "COP",
-		},
-		{
-			ID:       6,
-			Name:     "Reds",
-			Brewery:  "Bavaria",
-			Country:  "Colombia",
-			Price:    3,
-			Currency: "EUR",
-		},
+	_, err = beerRepository.Find(ctx, 1)
+	if !errors.Is(err, errors.ErrDeadlineExceeded) {
+		t.Errorf("finding beer out of time must return ErrDeadlineExceeded but return %v", err)
 	}
-
-	beerRepository := NewBeerRepository(client, tableBeers, logrus.New())
-
-	for _, beer := range beersToSave {
-		err := beerRepository.Save(beer)
-		if err != nil {
-			t.Errorf("error saving berr %v", err)
-		}
+	_, _, err = beerRepository.ListPage(ctx, ListFilter{}, 10, nil)
+	if !errors.Is(err, errors.ErrDeadlineExceeded) {
+		t.Errorf("listing beers out of time must return ErrDeadlineExceeded but return %v", err)
 	}
-
-	beersGot, err := beerRepository.List()
-	if err != nil {
-		t.Errorf("error listing beer %v", err)
+	_, unprocessed, err := beerRepository.SaveBatch(ctx, []model.Beer{{ID: 1}, {ID: 2}})
+	if !errors.Is(err, errors.ErrDeadlineExceeded) {
+		t.Errorf("saving beers out of time must return ErrDeadlineExceeded but return %v", err)
 	}
-
-	if len(beersGot) != len(beersToSave) {
-		t.Errorf("test must return %v elements but return %v elemens", len(beersGot), len(beersToSave))
+	if len(unprocessed) != 2 {
+		t.Errorf("saving beers out of time must return every ID as unprocessed but return %v", unprocessed)
 	}
-
 }
 
 //postfix function to build a postfix for test tables
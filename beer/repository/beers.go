@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+)
+
+//Beers contract of the beers stores, BeerRepository keeps them in dynamodb and MemoryRepository in memory.
+//Find returns a zero beer when the ID does not exist, the listings leave out soft deleted beers
+type Beers interface {
+	Find(ctx context.Context, ID int) (model.Beer, error)
+	FindMany(ctx context.Context, IDs []int) (map[int]model.Beer, error)
+	Save(ctx context.Context, beer model.Beer) error
+	SaveBatch(ctx context.Context, beers []model.Beer) (conflicts []int, unprocessed []int, err error)
+	NextID(ctx context.Context) (int, error)
+	Update(ctx context.Context, beer model.Beer, version int) (model.Beer, error)
+	SoftDelete(ctx context.Context, ID int) (model.Beer, error)
+	Restore(ctx context.Context, ID int) (model.Beer, error)
+	List(ctx context.Context) ([]model.Beer, error)
+	ListFiltered(ctx context.Context, filter ListFilter) ([]model.Beer, error)
+	ListPage(ctx context.Context, filter ListFilter, limit int, startKey map[string]string) ([]model.Beer, map[string]string, error)
+	ListByBrewery(ctx context.Context, brewery string) ([]model.Beer, error)
+	ListByCountry(ctx context.Context, country string) ([]model.Beer, error)
+}
+
+var (
+	_ Beers = (*BeerRepository)(nil)
+	_ Beers = (*MemoryRepository)(nil)
+)
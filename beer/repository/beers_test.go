@@ -0,0 +1,608 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/google/go-cmp/cmp"
+)
+
+//testBeers conformance suite every Beers implementation must pass, newBeers returns an empty store
+//for each scenario
+func testBeers(t *testing.T, newBeers func(t *testing.T) Beers) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, beerRepository Beers)
+	}{
+		{"SaveAndFind", testSaveAndFind},
+		{"NextID", testNextID},
+		{"ConcurrentWrites", testConcurrentWrites},
+		{"Update", testUpdate},
+		{"SoftDeleteAndRestore", testSoftDeleteAndRestore},
+		{"SaveAndList", testSaveAndList},
+		{"ListByBreweryAndCountry", testListByBreweryAndCountry},
+		{"SaveBatchAndFindMany", testSaveBatchAndFindMany},
+		{"ListFiltered", testListFiltered},
+		{"ListPageByCountry", testListPageByCountry},
+		{"ExpiredContext", testExpiredContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newBeers(t))
+		})
+	}
+}
+
+func testSaveAndFind(t *testing.T, beerRepository Beers) {
+	beerToSave := model.Beer{
+		ID:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    money.NewDecimal(2400, 0),
+		Currency: "COP",
+	}
+
+	ctx := context.Background()
+
+	err := beerRepository.Save(ctx, beerToSave)
+	if err != nil {
+		t.Errorf("error saving berr %v", err)
+	}
+
+	beerGot, err := beerRepository.Find(ctx, beerToSave.ID)
+	if err != nil {
+		t.Errorf("error finding beer %v", err)
+	}
+
+	beerToSave.Version = 1
+	if diff := cmp.Diff(beerToSave, beerGot); diff != "" {
+		t.Errorf("Error, saved beer is different than expected, (-want,+got)\n%s", diff)
+	}
+
+	beerGot, err = beerRepository.Find(ctx, 2)
+	if err != nil {
+		t.Errorf("error finding beer %v", err)
+	}
+
+	if beerGot.ID > 0 {
+		t.Errorf("the test musn't return any beer but return %v", beerGot)
+	}
+
+	err = beerRepository.Save(ctx, beerToSave)
+	if !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("saving an existing beer must return ErrAlreadyExists but return %v", err)
+	}
+}
+
+func testNextID(t *testing.T, beerRepository Beers) {
+	ctx := context.Background()
+
+	for want := 1; want <= 3; want++ {
+		ID, err := beerRepository.NextID(ctx)
+		if err != nil {
+			t.Fatalf("error allocating beer ID %v", err)
+		}
+		if ID != want {
+			t.Errorf("NextID() got = %d, want %d", ID, want)
+		}
+	}
+
+	err := beerRepository.Save(ctx, model.Beer{
+		ID:       4,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    money.NewDecimal(2400, 0),
+		Currency: "COP",
+	})
+	if err != nil {
+		t.Errorf("error saving beer %v", err)
+	}
+
+	beers, err := beerRepository.List(ctx)
+	if err != nil {
+		t.Errorf("error listing beers %v", err)
+	}
+	if len(beers) != 1 || beers[0].ID != 4 {
+		t.Errorf("the counter must not be listed but got %v", beers)
+	}
+}
+
+func testUpdate(t *testing.T, beerRepository Beers) {
+	beerToSave := model.Beer{
+		ID:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    money.NewDecimal(2400, 0),
+		Currency: "COP",
+	}
+
+	ctx := context.Background()
+
+	err := beerRepository.Save(ctx, beerToSave)
+	if err != nil {
+		t.Errorf("error saving berr %v", err)
+	}
+
+	beerToUpdate := beerToSave
+	beerToUpdate.Price = money.NewDecimal(2600, 0)
+
+	beerGot, err := beerRepository.Update(ctx, beerToUpdate, 1)
+	if err != nil {
+		t.Errorf("error updating beer %v", err)
+	}
+
+	beerToUpdate.Version = 2
+	if diff := cmp.Diff(beerToUpdate, beerGot); diff != "" {
+		t.Errorf("Error, updated beer is different than expected, (-want,+got)\n%s", diff)
+	}
+
+	_, err = beerRepository.Update(ctx, beerToUpdate, 1)
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("updating an old version must return ErrVersionConflict but return %v", err)
+	}
+
+	beerToUpdate.ID = 2
+	_, err = beerRepository.Update(ctx, beerToUpdate, 1)
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("updating a missing beer must return ErrVersionConflict but return %v", err)
+	}
+}
+
+func testSoftDeleteAndRestore(t *testing.T, beerRepository Beers) {
+	beerToSave := model.Beer{
+		ID:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    money.NewDecimal(2400, 0),
+		Currency: "COP",
+	}
+
+	ctx := context.Background()
+
+	err := beerRepository.Save(ctx, beerToSave)
+	if err != nil {
+		t.Errorf("error saving berr %v", err)
+	}
+
+	beerGot, err := beerRepository.SoftDelete(ctx, 1)
+	if err != nil {
+		t.Errorf("error deleting beer %v", err)
+	}
+	if beerGot.Active() || beerGot.Version != 2 {
+		t.Errorf("deleted beer must be inactive in version 2 but got %v", beerGot)
+	}
+
+	beerGot, err = beerRepository.Find(ctx, 1)
+	if err != nil {
+		t.Errorf("error finding beer %v", err)
+	}
+	if beerGot.Active() {
+		t.Errorf("deleted beer must still be found as inactive but got %v", beerGot)
+	}
+
+	beers, err := beerRepository.List(ctx)
+	if err != nil {
+		t.Errorf("error listing beers %v", err)
+	}
+	if len(beers) != 0 {
+		t.Errorf("deleted beers must not be listed but got %v", beers)
+	}
+
+	beerGot, err = beerRepository.Restore(ctx, 1)
+	if err != nil {
+		t.Errorf("error restoring beer %v", err)
+	}
+
+	beerToSave.Version = 3
+	if diff := cmp.Diff(beerToSave, beerGot); diff != "" {
+		t.Errorf("Error, restored beer is different than expected, (-want,+got)\n%s", diff)
+	}
+
+	_, err = beerRepository.SoftDelete(ctx, 2)
+	if !errors.Is(err, ErrBeerNotFound) {
+		t.Errorf("deleting a missing beer must return ErrBeerNotFound but return %v", err)
+	}
+}
+
+func testSaveAndList(t *testing.T, beerRepository Beers) {
+	beersToSave := []model.Beer{
+		{
+			ID:       1,
+			Name:     "Pilsen",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(2400, 0),
+			Currency: "COP",
+		},
+		{
+			ID:       2,
+			Name:     "Brava",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(2000, 0),
+			Currency: "COP",
+		},
+		{
+			ID:       3,
+			Name:     "Corona",
+			Brewery:  "Bavaria",
+			Country:  "Mexico",
+			Price:    money.NewDecimal(200, 0),
+			Currency: "MXN",
+		},
+		{
+			ID:       4,
+			Name:     "Budweiser",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(25, 1),
+			Currency: "USD",
+		},
+		{
+			ID:       5,
+			Name:     "Leona",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(2000, 0),
+			Currency: "COP",
+		},
+		{
+			ID:       6,
+			Name:     "Reds",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(3, 0),
+			Currency: "EUR",
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, beer := range beersToSave {
+		err := beerRepository.Save(ctx, beer)
+		if err != nil {
+			t.Errorf("error saving berr %v", err)
+		}
+	}
+
+	beersGot, err := beerRepository.List(ctx)
+	if err != nil {
+		t.Errorf("error listing beer %v", err)
+	}
+
+	if len(beersGot) != len(beersToSave) {
+		t.Errorf("test must return %v elements but return %v elemens", len(beersGot), len(beersToSave))
+	}
+
+	seen := map[int]bool{}
+	var lastKey map[string]string
+	for pages := 0; pages < len(beersToSave); pages++ {
+		page, next, err := beerRepository.ListPage(ctx, ListFilter{}, 4, lastKey)
+		if err != nil {
+			t.Errorf("error listing page of beers %v", err)
+			break
+		}
+		if len(page) > 4 {
+			t.Errorf("page must have at most 4 elements but has %v", len(page))
+		}
+		for _, beer := range page {
+			if seen[beer.ID] {
+				t.Errorf("beer %v was returned in more than one page", beer.ID)
+			}
+			seen[beer.ID] = true
+		}
+		if len(next) == 0 {
+			break
+		}
+		lastKey = next
+	}
+
+	if len(seen) != len(beersToSave) {
+		t.Errorf("pages must return %v elements but return %v elemens", len(beersToSave), len(seen))
+	}
+}
+
+func testListPageByCountry(t *testing.T, beerRepository Beers) {
+	ctx := context.Background()
+	for ID := 1; ID <= 6; ID++ {
+		country := "Colombia"
+		if ID%2 == 0 {
+			country = "Mexico"
+		}
+		beer := model.Beer{ID: ID, Name: "Beer " + strconv.Itoa(ID), Brewery: "Bavaria", Country: country, Price: money.NewDecimal(2400, 0), Currency: "COP"}
+		if err := beerRepository.Save(ctx, beer); err != nil {
+			t.Fatalf("error saving beer %v", err)
+		}
+	}
+	if _, err := beerRepository.SoftDelete(ctx, 5); err != nil {
+		t.Fatalf("error soft deleting beer %v", err)
+	}
+
+	filter := ListFilter{Country: "Colombia"}
+	var (
+		got     []int
+		lastKey map[string]string
+	)
+	for pages := 0; pages < 6; pages++ {
+		page, next, err := beerRepository.ListPage(ctx, filter, 1, lastKey)
+		if err != nil {
+			t.Fatalf("error listing page of beers %v", err)
+		}
+		for _, beer := range page {
+			got = append(got, beer.ID)
+		}
+		if len(next) == 0 {
+			break
+		}
+		lastKey = next
+	}
+	sort.Ints(got)
+	if diff := cmp.Diff([]int{1, 3}, got); diff != "" {
+		t.Errorf("pages of a country must have its active beers, (-want,+got)\n%s", diff)
+	}
+
+	_, next, err := beerRepository.ListPage(ctx, filter, 1, nil)
+	if err != nil || len(next) == 0 {
+		t.Fatalf("first page must have a next key but return %v, %v", next, err)
+	}
+	_, _, err = beerRepository.ListPage(ctx, ListFilter{Country: "Mexico"}, 1, next)
+	if !errors.Is(err, lib.ErrCursorNotValid) {
+		t.Errorf("a key of another country must return ErrCursorNotValid but return %v", err)
+	}
+}
+
+func testListByBreweryAndCountry(t *testing.T, beerRepository Beers) {
+	beersToSave := []model.Beer{
+		{ID: 1, Name: "Pilsen", Brewery: "Bavaria", Country: "Colombia", Price: money.NewDecimal(2400, 0), Currency: "COP"},
+		{ID: 2, Name: "Poker", Brewery: "Bavaria", Country: "Colombia", Price: money.NewDecimal(2000, 0), Currency: "COP"},
+		{ID: 3, Name: "Corona", Brewery: "Modelo", Country: "Mexico", Price: money.NewDecimal(200, 0), Currency: "MXN"},
+		{ID: 4, Name: "Negra Modelo", Brewery: "Modelo", Country: "Mexico", Price: money.NewDecimal(250, 0), Currency: "MXN"},
+	}
+
+	ctx := context.Background()
+	for _, beer := range beersToSave {
+		if err := beerRepository.Save(ctx, beer); err != nil {
+			t.Errorf("error saving berr %v", err)
+		}
+	}
+	if _, err := beerRepository.SoftDelete(ctx, 4); err != nil {
+		t.Errorf("error deleting beer %v", err)
+	}
+
+	ids := func(beers []model.Beer) []int {
+		var got []int
+		for _, beer := range beers {
+			got = append(got, beer.ID)
+		}
+		sort.Ints(got)
+		return got
+	}
+
+	beersGot, err := beerRepository.ListByBrewery(ctx, "Bavaria")
+	if err != nil {
+		t.Errorf("error listing beers by brewery %v", err)
+	}
+	if diff := cmp.Diff([]int{1, 2}, ids(beersGot)); diff != "" {
+		t.Errorf("Error, beers of brewery are different than expected, (-want,+got)\n%s", diff)
+	}
+
+	beersGot, err = beerRepository.ListByCountry(ctx, "Mexico")
+	if err != nil {
+		t.Errorf("error listing beers by country %v", err)
+	}
+	if diff := cmp.Diff([]int{3}, ids(beersGot)); diff != "" {
+		t.Errorf("Error, beers of country are different than expected, (-want,+got)\n%s", diff)
+	}
+
+	beersGot, err = beerRepository.ListByCountry(ctx, "Peru")
+	if err != nil {
+		t.Errorf("error listing beers by country %v", err)
+	}
+	if len(beersGot) != 0 {
+		t.Errorf("test musn't return any beer but return %v", beersGot)
+	}
+}
+
+func testSaveBatchAndFindMany(t *testing.T, beerRepository Beers) {
+	beersToSave := make([]model.Beer, 30)
+	IDs := make([]int, 0, len(beersToSave)+1)
+	for i := range beersToSave {
+		beersToSave[i] = model.Beer{
+			ID:       i + 1,
+			Name:     "Beer " + strconv.Itoa(i+1),
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(2400, 0),
+			Currency: "COP",
+		}
+		IDs = append(IDs, i+1)
+	}
+	IDs = append(IDs, 99)
+
+	ctx := context.Background()
+
+	conflicts, unprocessed, err := beerRepository.SaveBatch(ctx, beersToSave)
+	if err != nil {
+		t.Errorf("error saving batch of beers %v", err)
+	}
+	if len(conflicts) != 0 || len(unprocessed) != 0 {
+		t.Errorf("test musn't have conflicts or beers unprocessed but has %v and %v", conflicts, unprocessed)
+	}
+
+	found, err := beerRepository.FindMany(ctx, IDs)
+	if err != nil {
+		t.Errorf("error finding beers %v", err)
+	}
+	if len(found) != len(beersToSave) {
+		t.Errorf("test must find %v beers but found %v", len(beersToSave), len(found))
+	}
+
+	want := beersToSave[29]
+	want.Version = 1
+	if diff := cmp.Diff(want, found[30]); diff != "" {
+		t.Errorf("Error, saved beer is different than expected, (-want,+got)\n%s", diff)
+	}
+
+	deleted, err := beerRepository.SoftDelete(ctx, 30)
+	if err != nil {
+		t.Fatalf("error soft deleting beer %v", err)
+	}
+	replacement := beersToSave[29]
+	replacement.Name = "Replacement"
+	conflicts, unprocessed, err = beerRepository.SaveBatch(ctx, []model.Beer{replacement, {ID: 31, Name: "Beer 31", Currency: "COP"}})
+	if err != nil {
+		t.Errorf("error saving batch of beers %v", err)
+	}
+	if diff := cmp.Diff([]int{30}, conflicts); diff != "" || len(unprocessed) != 0 {
+		t.Errorf("saving an existing beer must be a conflict but return %v and %v", conflicts, unprocessed)
+	}
+	got, err := beerRepository.Find(ctx, 30)
+	if err != nil {
+		t.Errorf("error finding beer %v", err)
+	}
+	if diff := cmp.Diff(deleted, got); diff != "" {
+		t.Errorf("a conflict must keep the deleted beer, (-want,+got)\n%s", diff)
+	}
+}
+
+func testListFiltered(t *testing.T, beerRepository Beers) {
+	beersToSave := []model.Beer{
+		{ID: 1, Name: "Pilsen", Brewery: "Bavaria", Country: "Colombia", Price: money.NewDecimal(2400, 0), Currency: "COP"},
+		{ID: 2, Name: "Poker", Brewery: "Bavaria", Country: "Colombia", Price: money.NewDecimal(2000, 0), Currency: "COP"},
+		{ID: 3, Name: "Corona", Brewery: "Modelo", Country: "Mexico", Price: money.NewDecimal(200, 0), Currency: "MXN"},
+		{ID: 4, Name: "Club Colombia", Brewery: "Bavaria", Country: "Colombia", Price: money.NewDecimal(3000, 0), Currency: "COP"},
+	}
+
+	ctx := context.Background()
+	for _, beer := range beersToSave {
+		if err := beerRepository.Save(ctx, beer); err != nil {
+			t.Errorf("error saving berr %v", err)
+		}
+	}
+
+	minPrice := money.NewDecimal(2000, 0)
+	maxPrice := money.NewDecimal(2500, 0)
+	tests := []struct {
+		name   string
+		filter ListFilter
+		want   []int
+	}{
+		{
+			name:   "should_filter_by_country",
+			filter: ListFilter{Country: "Mexico"},
+			want:   []int{3},
+		},
+		{
+			name:   "should_filter_by_name_prefix_and_brewery",
+			filter: ListFilter{Brewery: "Bavaria", NamePrefix: "P"},
+			want:   []int{1, 2},
+		},
+		{
+			name:   "should_filter_by_price_range",
+			filter: ListFilter{Currency: "COP", MinPrice: &minPrice, MaxPrice: &maxPrice},
+			want:   []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			beersGot, err := beerRepository.ListFiltered(ctx, tt.filter)
+			if err != nil {
+				t.Errorf("error listing beers %v", err)
+			}
+			var got []int
+			for _, beer := range beersGot {
+				got = append(got, beer.ID)
+			}
+			sort.Ints(got)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Error, filtered beers are different than expected, (-want,+got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func testConcurrentWrites(t *testing.T, beerRepository Beers) {
+	ctx := context.Background()
+	const writers = 10
+
+	var (
+		wg      sync.WaitGroup
+		mutex   sync.Mutex
+		IDs     = map[int]bool{}
+		created int
+	)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ID, err := beerRepository.NextID(ctx)
+			if err != nil {
+				t.Errorf("error allocating beer ID %v", err)
+				return
+			}
+			err = beerRepository.Save(ctx, model.Beer{
+				ID:       100,
+				Name:     "Beer " + strconv.Itoa(i),
+				Brewery:  "Bavaria",
+				Country:  "Colombia",
+				Price:    money.NewDecimal(2400, 0),
+				Currency: "COP",
+			})
+			if err != nil && !errors.Is(err, ErrAlreadyExists) {
+				t.Errorf("error saving beer %v", err)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if IDs[ID] {
+				t.Errorf("NextID() gave %d twice", ID)
+			}
+			IDs[ID] = true
+			if err == nil {
+				created++
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if created != 1 {
+		t.Errorf("only one of the beers with the same ID must be created but %d were", created)
+	}
+}
+
+func testExpiredContext(t *testing.T, beerRepository Beers) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := beerRepository.Find(ctx, 1)
+	if !errors.Is(err, errors.ErrDeadlineExceeded) {
+		t.Errorf("finding beer out of time must return ErrDeadlineExceeded but return %v", err)
+	}
+	err = beerRepository.Save(ctx, model.Beer{ID: 1})
+	if !errors.Is(err, errors.ErrDeadlineExceeded) {
+		t.Errorf("saving beer out of time must return ErrDeadlineExceeded but return %v", err)
+	}
+	_, _, err = beerRepository.ListPage(ctx, ListFilter{}, 10, nil)
+	if !errors.Is(err, errors.ErrDeadlineExceeded) {
+		t.Errorf("listing beers out of time must return ErrDeadlineExceeded but return %v", err)
+	}
+	_, unprocessed, err := beerRepository.SaveBatch(ctx, []model.Beer{{ID: 1}, {ID: 2}})
+	if !errors.Is(err, errors.ErrDeadlineExceeded) {
+		t.Errorf("saving beers out of time must return ErrDeadlineExceeded but return %v", err)
+	}
+	if len(unprocessed) != 2 {
+		t.Errorf("saving beers out of time must return every ID as unprocessed but return %v", unprocessed)
+	}
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+)
+
+//ListFilter criteria to narrow the listing of beers, empty fields are not applied.
+//Prices are compared as plain amounts so MinPrice and MaxPrice must go with Currency, the name prefix is case sensitive
+type ListFilter struct {
+	Country    string
+	Brewery    string
+	Currency   string
+	NamePrefix string
+	MinPrice   *money.Decimal
+	MaxPrice   *money.Decimal
+}
+
+//indexKey index that narrows the listing the most with its hash key and the value it is queried by,
+//by_country or by_brewery when the filter has them and by_active otherwise
+func (f ListFilter) indexKey() (string, string, string) {
+	switch {
+	case f.Country != "":
+		return "by_country", "country", f.Country
+	case f.Brewery != "":
+		return "by_brewery", "brewery", f.Brewery
+	}
+	return "by_active", "active", "1"
+}
+
+//query builds the query over the index of the filter, the criteria that are not its hash key go in the
+//filter expression. Dynamodb applies it after reading the page so pages can come back with fewer items than the limit
+func (f ListFilter) query(table string) *dynamodb.QueryInput {
+	index, key, value := f.indexKey()
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(table),
+		IndexName:                 aws.String(index),
+		KeyConditionExpression:    aws.String("#" + key + " = :" + key),
+		ExpressionAttributeNames:  map[string]*string{"#" + key: aws.String(key)},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":" + key: toAttributeValues(map[string]string{key: value})[key]},
+	}
+
+	var conditions []string
+	add := func(condition, attribute, placeholder string, value *dynamodb.AttributeValue) {
+		conditions = append(conditions, condition)
+		input.ExpressionAttributeNames["#"+attribute] = aws.String(attribute)
+		input.ExpressionAttributeValues[placeholder] = value
+	}
+
+	if key != "active" {
+		add("#active = :active", "active", ":active", &dynamodb.AttributeValue{N: aws.String("1")})
+	}
+	if f.Brewery != "" && key != "brewery" {
+		add("#brewery = :brewery", "brewery", ":brewery", &dynamodb.AttributeValue{S: aws.String(f.Brewery)})
+	}
+	if f.Currency != "" {
+		add("#currency = :currency", "currency", ":currency", &dynamodb.AttributeValue{S: aws.String(f.Currency)})
+	}
+	if f.NamePrefix != "" {
+		add("begins_with(#name, :name_prefix)", "name", ":name_prefix", &dynamodb.AttributeValue{S: aws.String(f.NamePrefix)})
+	}
+	if f.MinPrice != nil {
+		add("#price >= :min_price", "price", ":min_price", &dynamodb.AttributeValue{N: aws.String(f.MinPrice.String())})
+	}
+	if f.MaxPrice != nil {
+		add("#price <= :max_price", "price", ":max_price", &dynamodb.AttributeValue{N: aws.String(f.MaxPrice.String())})
+	}
+
+	if len(conditions) > 0 {
+		input.FilterExpression = aws.String(strings.Join(conditions, " AND "))
+	}
+	return input
+}
+
+//continues indicates if startKey was returned by a query over the index of the filter, dynamodb
+//rejects the keys of another index or of another value of its hash key
+func (f ListFilter) continues(startKey map[string]string) bool {
+	_, key, value := f.indexKey()
+	return startKey[key] == value
+}
+
+//matches indicates if beer meets every criteria, the same comparisons apply does in dynamodb
+func (f ListFilter) matches(beer model.Beer) bool {
+	switch {
+	case f.Country != "" && beer.Country != f.Country,
+		f.Brewery != "" && beer.Brewery != f.Brewery,
+		f.Currency != "" && beer.Currency != f.Currency,
+		f.NamePrefix != "" && !strings.HasPrefix(beer.Name, f.NamePrefix),
+		f.MinPrice != nil && beer.Price.Cmp(*f.MinPrice) < 0,
+		f.MaxPrice != nil && beer.Price.Cmp(*f.MaxPrice) > 0:
+		return false
+	}
+	return true
+}
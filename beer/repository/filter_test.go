@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+)
+
+func TestListFilter_query(t *testing.T) {
+	minPrice := money.NewDecimal(2000, 0)
+	tests := []struct {
+		name          string
+		filter        ListFilter
+		wantIndex     string
+		wantCondition string
+		wantFilter    string
+	}{
+		{
+			name:          "should_query_active_beers_without_filter",
+			filter:        ListFilter{},
+			wantIndex:     "by_active",
+			wantCondition: "#active = :active",
+			wantFilter:    "",
+		},
+		{
+			name:          "should_query_the_country_index",
+			filter:        ListFilter{Country: "Colombia", Brewery: "Bavaria", Currency: "COP", MinPrice: &minPrice},
+			wantIndex:     "by_country",
+			wantCondition: "#country = :country",
+			wantFilter:    "#active = :active AND #brewery = :brewery AND #currency = :currency AND #price >= :min_price",
+		},
+		{
+			name:          "should_query_the_brewery_index",
+			filter:        ListFilter{Brewery: "Bavaria", NamePrefix: "P"},
+			wantIndex:     "by_brewery",
+			wantCondition: "#brewery = :brewery",
+			wantFilter:    "#active = :active AND begins_with(#name, :name_prefix)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.filter.query("beers")
+			if got := aws.StringValue(input.IndexName); got != tt.wantIndex {
+				t.Errorf("query() index = %v, want %v", got, tt.wantIndex)
+			}
+			if got := aws.StringValue(input.KeyConditionExpression); got != tt.wantCondition {
+				t.Errorf("query() key condition = %v, want %v", got, tt.wantCondition)
+			}
+			if got := aws.StringValue(input.FilterExpression); got != tt.wantFilter {
+				t.Errorf("query() filter = %v, want %v", got, tt.wantFilter)
+			}
+			if err := input.Validate(); err != nil {
+				t.Errorf("query() is not valid %v", err)
+			}
+		})
+	}
+}
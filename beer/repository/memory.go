@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+)
+
+//MemoryRepository beers kept in memory with the semantics of BeerRepository, for tests and local runs.
+//It is safe for concurrent use and pages are ordered by ID
+type MemoryRepository struct {
+	mutex  sync.RWMutex
+	beers  map[int]model.Beer
+	lastID int
+}
+
+//Find method to search a beer
+func (m *MemoryRepository) Find(ctx context.Context, ID int) (model.Beer, error) {
+	if err := done(ctx); err != nil {
+		return model.Beer{}, err
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return clone(m.beers[ID]), nil
+}
+
+//FindMany method to search several beers at once, the result only has the beers that exist
+func (m *MemoryRepository) FindMany(ctx context.Context, IDs []int) (map[int]model.Beer, error) {
+	if err := done(ctx); err != nil {
+		return nil, err
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	found := make(map[int]model.Beer, len(IDs))
+	for _, ID := range IDs {
+		if beer, ok := m.beers[ID]; ok {
+			found[ID] = clone(beer)
+		}
+	}
+	return found, nil
+}
+
+//Save method to save a new beer, it returns ErrAlreadyExists when the ID is taken
+func (m *MemoryRepository) Save(ctx context.Context, beer model.Beer) error {
+	if err := done(ctx); err != nil {
+		return err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.beers[beer.ID]; ok {
+		return ErrAlreadyExists
+	}
+	m.beers[beer.ID] = newBeer(beer)
+	return nil
+}
+
+//SaveBatch method to save new beers with Save, it returns the IDs that were taken and the ones that
+//could not be saved. Every ID is returned as unprocessed when ctx is done
+func (m *MemoryRepository) SaveBatch(ctx context.Context, beers []model.Beer) ([]int, []int, error) {
+	errs := make([]error, len(beers))
+	for i, beer := range beers {
+		errs[i] = m.Save(ctx, beer)
+	}
+	return batchOutcome(beers, errs)
+}
+
+//NextID method to allocate a beer ID, IDs are never given twice but they may be taken already by
+//beers created with an ID chosen by the client
+func (m *MemoryRepository) NextID(ctx context.Context) (int, error) {
+	if err := done(ctx); err != nil {
+		return 0, err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.lastID++
+	return m.lastID, nil
+}
+
+//Update method to replace the attributes of a beer only if it is still in the expected version,
+//a soft deleted beer keeps being deleted
+func (m *MemoryRepository) Update(ctx context.Context, beer model.Beer, version int) (model.Beer, error) {
+	if err := done(ctx); err != nil {
+		return model.Beer{}, err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	stored, ok := m.beers[beer.ID]
+	if !ok || stored.Version != version {
+		return model.Beer{}, ErrVersionConflict
+	}
+	beer.Version = version + 1
+	beer.DeletedAt = stored.DeletedAt
+	m.beers[beer.ID] = beer
+	return clone(beer), nil
+}
+
+//SoftDelete method to take a beer out of the listings keeping its data, the version is bumped
+func (m *MemoryRepository) SoftDelete(ctx context.Context, ID int) (model.Beer, error) {
+	deletedAt := time.Now().UTC().Truncate(time.Second)
+	return m.setDeletedAt(ctx, ID, &deletedAt)
+}
+
+//Restore method to list again a soft deleted beer
+func (m *MemoryRepository) Restore(ctx context.Context, ID int) (model.Beer, error) {
+	return m.setDeletedAt(ctx, ID, nil)
+}
+
+//setDeletedAt method to change the deletion time of an existing beer bumping its version
+func (m *MemoryRepository) setDeletedAt(ctx context.Context, ID int, deletedAt *time.Time) (model.Beer, error) {
+	if err := done(ctx); err != nil {
+		return model.Beer{}, err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	beer, ok := m.beers[ID]
+	if !ok {
+		return model.Beer{}, ErrBeerNotFound
+	}
+	beer.DeletedAt = deletedAt
+	beer.Version++
+	m.beers[ID] = beer
+	return clone(beer), nil
+}
+
+//List method to list all beers
+func (m *MemoryRepository) List(ctx context.Context) ([]model.Beer, error) {
+	return m.ListFiltered(ctx, ListFilter{})
+}
+
+//ListFiltered method to list all active beers matching the filter
+func (m *MemoryRepository) ListFiltered(ctx context.Context, filter ListFilter) ([]model.Beer, error) {
+	beers, _, err := m.ListPage(ctx, filter, 0, nil)
+	return beers, err
+}
+
+//ListPage method to list one page of active beers matching the filter starting after startKey, limit 0
+//returns every beer. Keys hold the hash key of the index dynamodb would query, the returned key is
+//empty when there are no more pages
+func (m *MemoryRepository) ListPage(
+	ctx context.Context,
+	filter ListFilter,
+	limit int,
+	startKey map[string]string,
+) ([]model.Beer, map[string]string, error) {
+	if err := done(ctx); err != nil {
+		return []model.Beer{}, nil, err
+	}
+	after := 0
+	if len(startKey) > 0 {
+		ID, err := strconv.Atoi(startKey["id"])
+		if err != nil || !filter.continues(startKey) {
+			return []model.Beer{}, nil, lib.ErrCursorNotValid
+		}
+		after = ID
+	}
+
+	beers := m.active(func(beer model.Beer) bool {
+		return beer.ID > after && filter.matches(beer)
+	})
+	if limit > 0 && len(beers) > limit {
+		beers = beers[:limit]
+		_, key, value := filter.indexKey()
+		return beers, map[string]string{"id": strconv.Itoa(beers[limit-1].ID), key: value}, nil
+	}
+	return beers, nil, nil
+}
+
+//ListByBrewery method to list the active beers of a brewery
+func (m *MemoryRepository) ListByBrewery(ctx context.Context, brewery string) ([]model.Beer, error) {
+	return m.ListFiltered(ctx, ListFilter{Brewery: brewery})
+}
+
+//ListByCountry method to list the active beers of a country
+func (m *MemoryRepository) ListByCountry(ctx context.Context, country string) ([]model.Beer, error) {
+	return m.ListFiltered(ctx, ListFilter{Country: country})
+}
+
+//active method to list the active beers accepted by keep ordered by ID
+func (m *MemoryRepository) active(keep func(model.Beer) bool) []model.Beer {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	beers := []model.Beer{}
+	for _, beer := range m.beers {
+		if beer.Active() && keep(beer) {
+			beers = append(beers, clone(beer))
+		}
+	}
+	sort.Slice(beers, func(i, j int) bool {
+		return beers[i].ID < beers[j].ID
+	})
+	return beers
+}
+
+//newBeer beer as it is stored the first time, active in version 1
+func newBeer(beer model.Beer) model.Beer {
+	beer.Version = 1
+	beer.DeletedAt = nil
+	return beer
+}
+
+//clone copy of beer that does not share its deletion time with the stored one
+func clone(beer model.Beer) model.Beer {
+	if beer.DeletedAt != nil {
+		deletedAt := *beer.DeletedAt
+		beer.DeletedAt = &deletedAt
+	}
+	return beer
+}
+
+//done returns the timeout error of ctx once it is done, the calls to dynamodb fail the same way
+func done(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return errors.FromContext(ctx, err)
+	}
+	return nil
+}
+
+//NewMemoryRepository construct for an empty in memory repository
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		beers: map[int]model.Beer{},
+	}
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+)
+
+func TestMemoryRepository_Conformance(t *testing.T) {
+	testBeers(t, func(t *testing.T) Beers {
+		return NewMemoryRepository()
+	})
+}
+
+func TestMemoryRepository_returnsCopies(t *testing.T) {
+	beerRepository := NewMemoryRepository()
+	ctx := context.Background()
+
+	if err := beerRepository.Save(ctx, model.Beer{ID: 1, Name: "Pilsen"}); err != nil {
+		t.Fatalf("error saving beer %v", err)
+	}
+	deleted, err := beerRepository.SoftDelete(ctx, 1)
+	if err != nil {
+		t.Fatalf("error deleting beer %v", err)
+	}
+	*deleted.DeletedAt = deleted.DeletedAt.AddDate(1, 0, 0)
+
+	beerGot, err := beerRepository.Find(ctx, 1)
+	if err != nil {
+		t.Fatalf("error finding beer %v", err)
+	}
+	if beerGot.DeletedAt.Equal(*deleted.DeletedAt) {
+		t.Errorf("changing a returned beer must not change the stored one but got %v", beerGot.DeletedAt)
+	}
+}
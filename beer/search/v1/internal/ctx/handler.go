@@ -0,0 +1,119 @@
+package ctx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+
+	//indexTTL time a warm lambda reuses the index before reading the catalog again
+	indexTTL = time.Minute
+)
+
+//beerRepositoryInterface contract for beer repository
+type beerRepositoryInterface interface {
+	List(context.Context) ([]model.Beer, error)
+}
+
+//responseLambda beers matching the search, the best ranked first
+type responseLambda struct {
+	Items []result `json:"items"`
+}
+
+//Handler main struct for lambda
+type Handler struct {
+	beersRepository beerRepositoryInterface
+	logger          *logrus.Logger
+
+	mu      sync.Mutex
+	idx     *index
+	builtAt time.Time
+}
+
+//Handler main function for lambda
+func (h *Handler) Handler(
+	ctx context.Context,
+	req events.APIGatewayProxyRequest,
+) (events.APIGatewayProxyResponse, error) {
+	query := req.QueryStringParameters["q"]
+	logger := lib.Logger(ctx, h.logger).WithField("query", query)
+	logger.Info("Beginning of execution of lambda")
+
+	if strings.TrimSpace(query) == "" {
+		return lib.ResponseError(req.Path, errors.Validation("q_can_not_be_empty")), nil
+	}
+
+	limit := defaultLimit
+	if limitString := req.QueryStringParameters["limit"]; strings.TrimSpace(limitString) != "" {
+		var err error
+		limit, err = strconv.Atoi(limitString)
+		if err != nil {
+			return lib.ResponseError(req.Path, errors.Validation("limit_is_not_a_number")), nil
+		}
+		if limit < 1 || limit > maxLimit {
+			return lib.ResponseError(req.Path, errors.Validation("limit_is_out_of_range")), nil
+		}
+	}
+
+	idx, err := h.index(ctx)
+	if err != nil {
+		logger.WithError(err).Error("error building search index")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	results := idx.search(query)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	response, err := json.Marshal(responseLambda{Items: results})
+	if err != nil {
+		logger.WithError(err).Error("error marshaling results")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	return lib.JSONResponse(http.StatusOK, response), nil
+}
+
+//index returns the index of the catalog, building it again once it is older than indexTTL
+func (h *Handler) index(ctx context.Context) (*index, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.idx != nil && time.Since(h.builtAt) < indexTTL {
+		return h.idx, nil
+	}
+
+	beers, err := h.beersRepository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	h.idx = newIndex(beers)
+	h.builtAt = time.Now()
+	return h.idx, nil
+}
+
+//NewHandler construct for Handler
+func NewHandler(
+	beersRepository beerRepositoryInterface,
+	logger *logrus.Logger,
+) *Handler {
+	return &Handler{
+		beersRepository: beersRepository,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,252 @@
+package ctx
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+//beerRepositoryMock struct to simulate beer repository behavior
+type beerRepositoryMock struct {
+	mock.Mock
+}
+
+func (b *beerRepositoryMock) List(_ context.Context) ([]model.Beer, error) {
+	args := b.Called()
+	return args.Get(0).([]model.Beer), args.Error(1)
+}
+
+//go:embed golden_files/successResponse.json
+var successResponse []byte
+
+func TestHandler_Handler(t *testing.T) {
+	beersToReturn := []model.Beer{
+		{
+			ID:       1,
+			Name:     "Águila",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(2200, 0),
+			Currency: "COP",
+		},
+		{
+			ID:       3,
+			Name:     "Corona",
+			Brewery:  "Modelo",
+			Country:  "México",
+			Price:    money.NewDecimal(200, 0),
+			Currency: "MXN",
+		},
+		{
+			ID:       4,
+			Name:     "Club Colombia",
+			Brewery:  "Bavaria",
+			Country:  "Colombia",
+			Price:    money.NewDecimal(3000, 0),
+			Currency: "COP",
+		},
+	}
+	headers := map[string]string{
+		"Content-Type":                     "application/json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+	problemHeaders := map[string]string{
+		"Content-Type":                     "application/problem+json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+
+	type mocks struct {
+		beersRepository *beerRepositoryMock
+	}
+	type fields struct {
+		logger *logrus.Logger
+	}
+	type args struct {
+		ctx context.Context
+		req events.APIGatewayProxyRequest
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		mocks   mocks
+		mocker  func(m mocks)
+		want    events.APIGatewayProxyResponse
+		wantErr bool
+	}{
+		{
+			name: "should_return_error_because_query_is_not_present",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/q_can_not_be_empty","title":"Bad Request","status":400,"detail":"q_can_not_be_empty"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_limit_is_out_of_range",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					QueryStringParameters: map[string]string{
+						"q":     "aguila",
+						"limit": "0",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/limit_is_out_of_range","title":"Bad Request","status":400,"detail":"limit_is_out_of_range"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_listing_beers",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					QueryStringParameters: map[string]string{
+						"q": "aguila",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("List").Return([]model.Beer{}, errors.New("error")).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_no_results",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					QueryStringParameters: map[string]string{
+						"q": "stout",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("List").Return(beersToReturn, nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       `{"items":[]}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_ranked_results",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					QueryStringParameters: map[string]string{
+						"q":     "COLOMBIA",
+						"limit": "2",
+					},
+				},
+			},
+			mocks: mocks{
+				beersRepository: &beerRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("List").Return(append(beersToReturn, model.Beer{
+					ID:       5,
+					Name:     "Pilsen",
+					Brewery:  "Bavaria",
+					Country:  "Colombia",
+					Price:    money.NewDecimal(2400, 0),
+					Currency: "COP",
+				}), nil).Once()
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    headers,
+				Body:       string(successResponse),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocker(tt.mocks)
+			h := NewHandler(tt.mocks.beersRepository, tt.fields.logger)
+			got, err := h.Handler(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Handler() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Handler() got = %v, want %v", got, tt.want)
+			}
+			tt.mocks.beersRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_Handler_reusesIndex(t *testing.T) {
+	beersRepository := &beerRepositoryMock{}
+	beersRepository.On("List").Return([]model.Beer{{ID: 1, Name: "Águila"}}, nil).Once()
+
+	h := NewHandler(beersRepository, logrus.New())
+	for i := 0; i < 2; i++ {
+		got, _ := h.Handler(context.Background(), events.APIGatewayProxyRequest{
+			QueryStringParameters: map[string]string{"q": "aguila"},
+		})
+		if got.StatusCode != http.StatusOK {
+			t.Errorf("Handler() status = %v, want %v", got.StatusCode, http.StatusOK)
+		}
+	}
+	beersRepository.AssertExpectations(t)
+}
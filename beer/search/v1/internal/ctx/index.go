@@ -0,0 +1,220 @@
+package ctx
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+)
+
+const (
+	exactScore  = 1.0
+	prefixScore = 0.75
+	typoScore   = 0.5
+
+	minPrefixLength = 2
+)
+
+//fieldWeights how much a match in each field of the beer counts
+var fieldWeights = map[string]float64{
+	"name":    3,
+	"brewery": 2,
+	"country": 1,
+}
+
+//foldings letters with diacritics and the letter they are searched as
+var foldings = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ä': 'a', 'ã': 'a', 'å': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'ö': 'o', 'õ': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ñ': 'n', 'ç': 'c',
+}
+
+//posting occurrence of a term in a field of a beer
+type posting struct {
+	beer  int
+	field string
+}
+
+//result beer matching a search with its relevance
+type result struct {
+	model.Beer
+	Score float64 `json:"score"`
+}
+
+//index inverted index of the terms in name, brewery and country of the beers
+type index struct {
+	beers    []model.Beer
+	postings map[string][]posting
+}
+
+//search returns the beers matching every term of the query, the best ranked first
+func (i *index) search(query string) []result {
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return []result{}
+	}
+
+	var scores map[int]float64
+	for _, term := range terms {
+		termScores := i.score(term)
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for beer, score := range scores {
+			if termScore, ok := termScores[beer]; ok {
+				scores[beer] = score + termScore
+				continue
+			}
+			delete(scores, beer)
+		}
+	}
+
+	results := make([]result, 0, len(scores))
+	for beer, score := range scores {
+		results = append(results, result{
+			Beer:  i.beers[beer],
+			Score: float64(int(score*1000+0.5)) / 1000,
+		})
+	}
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].ID < results[b].ID
+	})
+	return results
+}
+
+//score best score of a query term for each beer, comparing it with every term of the index
+func (i *index) score(term string) map[int]float64 {
+	scores := map[int]float64{}
+	for indexed, postings := range i.postings {
+		match := matchScore(term, indexed)
+		if match == 0 {
+			continue
+		}
+		for _, p := range postings {
+			score := match * fieldWeights[p.field]
+			if score > scores[p.beer] {
+				scores[p.beer] = score
+			}
+		}
+	}
+	return scores
+}
+
+//matchScore how well a query term matches an indexed term, 0 means they do not match
+func matchScore(term, indexed string) float64 {
+	if term == indexed {
+		return exactScore
+	}
+	if len([]rune(term)) >= minPrefixLength && strings.HasPrefix(indexed, term) {
+		return prefixScore
+	}
+	allowed := allowedTypos(term)
+	if allowed > 0 && editDistance(term, indexed, allowed) <= allowed {
+		return typoScore
+	}
+	return 0
+}
+
+//allowedTypos short terms must be spelled right, longer ones admit one or two edits
+func allowedTypos(term string) int {
+	switch length := len([]rune(term)); {
+	case length >= 8:
+		return 2
+	case length >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+//editDistance optimal string alignment distance, a swap of adjacent letters counts as one edit.
+//It stops early returning max+1 once the distance is over max
+func editDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+			if curr[j] < rowMin {
+				rowMin = curr[j]
+			}
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+//minInt smallest of the values
+func minInt(values ...int) int {
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+//tokenize splits a text in lower case terms without accents
+func tokenize(text string) []string {
+	folded := strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if f, ok := foldings[r]; ok {
+			return f
+		}
+		return r
+	}, text)
+	return strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+//newIndex builds the index of the beers
+func newIndex(beers []model.Beer) *index {
+	idx := &index{
+		beers:    beers,
+		postings: map[string][]posting{},
+	}
+	for i, beer := range beers {
+		fields := map[string]string{
+			"name":    beer.Name,
+			"brewery": beer.Brewery,
+			"country": beer.Country,
+		}
+		for field, text := range fields {
+			for _, term := range tokenize(text) {
+				idx.postings[term] = append(idx.postings[term], posting{beer: i, field: field})
+			}
+		}
+	}
+	return idx
+}
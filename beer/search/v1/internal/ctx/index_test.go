@@ -0,0 +1,127 @@
+package ctx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+)
+
+func Test_tokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "should_remove_accents_and_case",
+			text: "Águila Light",
+			want: []string{"aguila", "light"},
+		},
+		{
+			name: "should_split_on_punctuation",
+			text: "Cervecería del Valle-Ñandú 3",
+			want: []string{"cerveceria", "del", "valle", "nandu", "3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenize() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		max  int
+		want int
+	}{
+		{name: "should_count_a_missing_letter", a: "pilsn", b: "pilsen", max: 2, want: 1},
+		{name: "should_count_a_swap_as_one_edit", a: "crona", b: "corna", max: 2, want: 1},
+		{name: "should_count_a_substitution", a: "poker", b: "pokar", max: 2, want: 1},
+		{name: "should_stop_over_the_max", a: "corona", b: "pilsen", max: 1, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b, tt.max); got != tt.want {
+				t.Errorf("editDistance() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_index_search(t *testing.T) {
+	beers := []model.Beer{
+		{ID: 1, Name: "Águila", Brewery: "Bavaria", Country: "Colombia", Price: money.NewDecimal(2200, 0), Currency: "COP"},
+		{ID: 2, Name: "Pilsen", Brewery: "Bavaria", Country: "Colombia", Price: money.NewDecimal(2400, 0), Currency: "COP"},
+		{ID: 3, Name: "Corona", Brewery: "Modelo", Country: "México", Price: money.NewDecimal(200, 0), Currency: "MXN"},
+		{ID: 4, Name: "Club Colombia", Brewery: "Bavaria", Country: "Colombia", Price: money.NewDecimal(3000, 0), Currency: "COP"},
+	}
+	idx := newIndex(beers)
+
+	tests := []struct {
+		name  string
+		query string
+		want  map[int]float64
+		order []int
+	}{
+		{
+			name:  "should_match_without_accents",
+			query: "aguila",
+			order: []int{1},
+			want:  map[int]float64{1: 3},
+		},
+		{
+			name:  "should_rank_name_matches_over_country_matches",
+			query: "colombia",
+			order: []int{4, 1, 2},
+			want:  map[int]float64{4: 3, 1: 1, 2: 1},
+		},
+		{
+			name:  "should_tolerate_typos",
+			query: "pilsn",
+			order: []int{2},
+			want:  map[int]float64{2: 1.5},
+		},
+		{
+			name:  "should_match_prefixes",
+			query: "cor",
+			order: []int{3},
+			want:  map[int]float64{3: 2.25},
+		},
+		{
+			name:  "should_require_every_term",
+			query: "bavaria mexico",
+			order: []int{},
+			want:  map[int]float64{},
+		},
+		{
+			name:  "should_return_nothing_for_an_empty_query",
+			query: " - ",
+			order: []int{},
+			want:  map[int]float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := idx.search(tt.query)
+			order := []int{}
+			scores := map[int]float64{}
+			for _, r := range results {
+				order = append(order, r.ID)
+				scores[r.ID] = r.Score
+			}
+			if !reflect.DeepEqual(order, tt.order) {
+				t.Errorf("search() order = %v, want %v", order, tt.order)
+			}
+			if !reflect.DeepEqual(scores, tt.want) {
+				t.Errorf("search() scores = %v, want %v", scores, tt.want)
+			}
+		})
+	}
+}
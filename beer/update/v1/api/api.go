@@ -0,0 +1,32 @@
+//Package api exposes the handler of the update lambda to run it outside of API Gateway, see cmd/localapi
+package api
+
+import (
+	"context"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/update/v1/internal/ctx"
+	"github.com/chandy20/prueba-smartjobandina/beer/update/v1/internal/di"
+	"github.com/sirupsen/logrus"
+)
+
+//Repository beers the handler writes, every repository.Beers satisfies it
+type Repository interface {
+	Find(context.Context, int) (model.Beer, error)
+	Update(context.Context, model.Beer, int) (model.Beer, error)
+}
+
+//New builds the handler of the lambda with the same dependencies its main uses
+func New() (lib.HandlerFunc, error) {
+	handler, err := di.Initialize()
+	if err != nil {
+		return nil, err
+	}
+	return handler.Handler, nil
+}
+
+//NewHandler builds the handler over repository, for callers that bring their own store
+func NewHandler(repository Repository, logger *logrus.Logger) lib.HandlerFunc {
+	return ctx.NewHandler(repository, logger).Handler
+}
@@ -0,0 +1,149 @@
+package ctx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib"
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/sirupsen/logrus"
+)
+
+//errBeerIDDoesNotMatch custom error to represent a body with a different id than the path
+var errBeerIDDoesNotMatch = errors.Validation("beerID_does_not_match")
+
+//errIfMatchIsNotValid custom error to represent an If-Match header that is not a beer version
+var errIfMatchIsNotValid = errors.Validation("if_match_is_not_valid")
+
+//errIfMatchIsRequired custom error to represent a change sent without If-Match nor version in body
+var errIfMatchIsRequired = errors.PreconditionRequired("if_match_is_required")
+
+//beerRepositoryInterface contract for beer repository
+type beerRepositoryInterface interface {
+	Find(context.Context, int) (model.Beer, error)
+	Update(context.Context, model.Beer, int) (model.Beer, error)
+}
+
+//Handler main struct for lambda
+type Handler struct {
+	beersRepository beerRepositoryInterface
+	logger          *logrus.Logger
+}
+
+//Handler main function for lambda, PUT replaces the beer and PATCH applies a json merge patch
+func (h *Handler) Handler(
+	ctx context.Context,
+	req events.APIGatewayProxyRequest,
+) (events.APIGatewayProxyResponse, error) {
+	logger := lib.Logger(ctx, h.logger).WithField("request_body", req.Body)
+	logger.Info("Beginning of execution of lambda")
+
+	IDString := req.PathParameters["beerID"]
+	if strings.TrimSpace(IDString) == "" {
+		return lib.ResponseError(req.Path, errors.Validation("beerID_can_not_be_empty")), nil
+	}
+
+	ID, err := strconv.Atoi(IDString)
+	if err != nil {
+		return lib.ResponseError(req.Path, errors.Validation("beerID_is_not_a_number")), nil
+	}
+
+	current, err := h.beersRepository.Find(ctx, ID)
+	if err != nil {
+		logger.WithError(err).Error("error finding beer")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	if current.ID == 0 {
+		return lib.ResponseError(req.Path, errors.NotFound("beerID_does_not_exist")), nil
+	}
+
+	if !current.Active() {
+		return lib.ResponseError(req.Path, errors.Gone("beerID_was_deleted")), nil
+	}
+
+	var beer model.Beer
+	if req.HTTPMethod == http.MethodPatch {
+		beer, err = mergePatch(current, []byte(req.Body))
+	} else {
+		err = json.Unmarshal([]byte(req.Body), &beer)
+		if err != nil {
+			logger.WithError(err).Error("error while unmarshalling")
+			return lib.ResponseError(req.Path, errBodyIsNotABeer), nil
+		}
+	}
+	if err != nil {
+		logger.WithError(err).Error("error while reading body")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	if beer.ID != 0 && beer.ID != ID {
+		return lib.ResponseError(req.Path, errBeerIDDoesNotMatch), nil
+	}
+	beer.ID = ID
+
+	version, err := expectedVersion(req)
+	if err != nil {
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	err = lib.ValidateBeer(&beer)
+	if err != nil {
+		logger.WithError(err).Error("validation errors found")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	updated, err := h.beersRepository.Update(ctx, beer, version)
+	if err != nil {
+		logger.WithError(err).WithField("version", version).Error("error updating beer")
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	response, err := json.Marshal(updated)
+	if err != nil {
+		return lib.ResponseError(req.Path, err), nil
+	}
+
+	resp := lib.JSONResponse(http.StatusOK, response)
+	resp.Headers["ETag"] = lib.ETag(updated.Version)
+	return resp, nil
+}
+
+//expectedVersion version the client read before changing the beer, taken from If-Match header or
+//else from the version in body. One of them is required so a change never overwrites another blindly,
+//the body is read again because a patch inherits the version of the stored beer
+func expectedVersion(req events.APIGatewayProxyRequest) (int, error) {
+	ifMatch := lib.Header(req, "If-Match")
+	if strings.TrimSpace(ifMatch) != "" {
+		version, err := lib.ParseETag(ifMatch)
+		if err != nil {
+			return 0, errIfMatchIsNotValid
+		}
+		return version, nil
+	}
+
+	var body struct {
+		Version int `json:"version"`
+	}
+	if json.Unmarshal([]byte(req.Body), &body) == nil && body.Version > 0 {
+		return body.Version, nil
+	}
+
+	return 0, errIfMatchIsRequired
+}
+
+//NewHandler construct for Handler
+func NewHandler(
+	beersRepository beerRepositoryInterface,
+	logger *logrus.Logger,
+) *Handler {
+	return &Handler{
+		beersRepository: beersRepository,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,521 @@
+package ctx
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+	"github.com/chandy20/prueba-smartjobandina/beer/money"
+	"github.com/chandy20/prueba-smartjobandina/beer/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+//beersRepositoryMock mock for represent beers repository
+type beersRepositoryMock struct {
+	mock.Mock
+}
+
+func (b *beersRepositoryMock) Find(_ context.Context, ID int) (model.Beer, error) {
+	args := b.Called(ID)
+	return args.Get(0).(model.Beer), args.Error(1)
+}
+
+func (b *beersRepositoryMock) Update(_ context.Context, beer model.Beer, version int) (model.Beer, error) {
+	args := b.Called(beer, version)
+	return args.Get(0).(model.Beer), args.Error(1)
+}
+
+//go:embed golden_files/replaceRequest.json
+var replaceRequest []byte
+
+//go:embed golden_files/successResponse.json
+var successResponse []byte
+
+func TestHandler_Handler(t *testing.T) {
+	problemHeaders := map[string]string{
+		"Content-Type":                     "application/problem+json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+	}
+	successHeaders := map[string]string{
+		"Content-Type":                     "application/json",
+		"Access-Control-Allow-Origin":      "*",
+		"Access-Control-Allow-Credentials": "true",
+		"ETag":                             `"2"`,
+	}
+
+	current := model.Beer{
+		ID:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    money.NewDecimal(2400, 0),
+		Currency: "COP",
+		Version:  1,
+	}
+	deletedAt := time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC)
+	deleted := current
+	deleted.DeletedAt = &deletedAt
+	changed := current
+	changed.Price = money.NewDecimal(2600, 0)
+	changed.Version = 0
+	updated := changed
+	updated.Version = 2
+
+	type mocks struct {
+		beersRepository *beersRepositoryMock
+	}
+
+	type fields struct {
+		logger *logrus.Logger
+	}
+
+	type args struct {
+		ctx context.Context
+		req events.APIGatewayProxyRequest
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		mocks   mocks
+		mocker  func(m mocks)
+		args    args
+		want    events.APIGatewayProxyResponse
+		wantErr bool
+	}{
+		{
+			name: "should_return_error_because_beer_id_is_not_a_number",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					PathParameters: map[string]string{
+						"beerID": "a",
+					},
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beerID_is_not_a_number","title":"Bad Request","status":400,"detail":"beerID_is_not_a_number"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_finding_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(model.Beer{}, errors.New("error")).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: string(replaceRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_beer_id_does_not_exist",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(model.Beer{}, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: string(replaceRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusNotFound,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beerID_does_not_exist","title":"Not Found","status":404,"detail":"beerID_does_not_exist"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_beer_was_deleted",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(deleted, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: string(replaceRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusGone,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beerID_was_deleted","title":"Gone","status":410,"detail":"beerID_was_deleted"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_body_id_does_not_match",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: `{"id":2,"name":"Pilsen","brewery":"Bavaria","country":"Colombia","price":2600,"currency":"COP"}`,
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beerID_does_not_match","title":"Bad Request","status":400,"detail":"beerID_does_not_match"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_if_match_is_not_valid",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					Headers: map[string]string{
+						"if-match": "*",
+					},
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: string(replaceRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/if_match_is_not_valid","title":"Bad Request","status":400,"detail":"if_match_is_not_valid"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_replacement_is_invalid",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					Headers: map[string]string{
+						"If-Match": `"1"`,
+					},
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: `{"name":"Pilsen","brewery":"Bavaria","country":"Colombia","price":2600,"currency":"ABC"}`,
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/beer_is_not_valid","title":"Bad Request","status":400,"detail":"beer_is_not_valid","errors":[{"pointer":"/currency","code":"currency_not_supported"}]}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_version_changed",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+				m.beersRepository.On("Update", changed, 1).Return(model.Beer{}, repository.ErrVersionConflict).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					Headers: map[string]string{
+						"If-Match": `"1"`,
+					},
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: string(replaceRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusPreconditionFailed,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/error_beer_version_conflict","title":"Precondition Failed","status":412,"detail":"error_beer_version_conflict"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_updating_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+				m.beersRepository.On("Update", changed, 1).Return(model.Beer{}, errors.New("error")).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					Headers: map[string]string{
+						"If-Match": `"1"`,
+					},
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: string(replaceRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusInternalServerError,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"detail":"internal_error"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_replacement_has_no_precondition",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: string(replaceRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusPreconditionRequired,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/if_match_is_required","title":"Precondition Required","status":428,"detail":"if_match_is_required"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_patch_has_no_precondition",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPatch,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: `{"price":2600}`,
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusPreconditionRequired,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/if_match_is_required","title":"Precondition Required","status":428,"detail":"if_match_is_required"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_replace_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+				m.beersRepository.On("Update", changed, 1).Return(updated, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPut,
+					Headers: map[string]string{
+						"If-Match": `"1"`,
+					},
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: string(replaceRequest),
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    successHeaders,
+				Body:       string(successResponse),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_return_error_because_patch_is_not_an_object",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPatch,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: `[{"op":"replace","path":"/price","value":2600}]`,
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers:    problemHeaders,
+				Body:       `{"type":"/problems/patch_must_be_a_json_object","title":"Bad Request","status":400,"detail":"patch_must_be_a_json_object"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_patch_beer",
+			fields: fields{
+				logger: logrus.New(),
+			},
+			mocks: mocks{
+				beersRepository: &beersRepositoryMock{},
+			},
+			mocker: func(m mocks) {
+				m.beersRepository.On("Find", 1).Return(current, nil).Once()
+				patched := changed
+				patched.Version = 1
+				m.beersRepository.On("Update", patched, 1).Return(updated, nil).Once()
+			},
+			args: args{
+				ctx: context.Background(),
+				req: events.APIGatewayProxyRequest{
+					HTTPMethod: http.MethodPatch,
+					PathParameters: map[string]string{
+						"beerID": "1",
+					},
+					Body: `{"price":2600,"version":1}`,
+				},
+			},
+			want: events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Headers:    successHeaders,
+				Body:       string(successResponse),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocker(tt.mocks)
+			h := NewHandler(tt.mocks.beersRepository, tt.fields.logger)
+			got, err := h.Handler(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Handler() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Handler() got = %v, want %v", got, tt.want)
+			}
+
+			tt.mocks.beersRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package ctx
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/chandy20/prueba-smartjobandina/beer/lib/errors"
+	"github.com/chandy20/prueba-smartjobandina/beer/model"
+)
+
+//errPatchIsNotAnObject custom error to represent a merge patch that is not a json object
+var errPatchIsNotAnObject = errors.Validation("patch_must_be_a_json_object")
+
+//errBodyIsNotABeer custom error to represent a body, or a patched beer, that can not be read as a beer
+var errBodyIsNotABeer = errors.Validation("body_is_not_a_beer")
+
+//mergePatch applies a json merge patch (RFC 7396) to beer
+func mergePatch(beer model.Beer, patch []byte) (model.Beer, error) {
+	var changes map[string]interface{}
+	err := decode(patch, &changes)
+	if err != nil || changes == nil {
+		return model.Beer{}, errPatchIsNotAnObject
+	}
+
+	original, err := json.Marshal(beer)
+	if err != nil {
+		return model.Beer{}, err
+	}
+
+	var target map[string]interface{}
+	err = decode(original, &target)
+	if err != nil {
+		return model.Beer{}, err
+	}
+
+	merged, err := json.Marshal(merge(target, changes))
+	if err != nil {
+		return model.Beer{}, err
+	}
+
+	var patched model.Beer
+	err = json.Unmarshal(merged, &patched)
+	if err != nil {
+		return model.Beer{}, errBodyIsNotABeer
+	}
+
+	return patched, nil
+}
+
+//merge copies every change into target, null values remove the member
+func merge(target, changes map[string]interface{}) map[string]interface{} {
+	for key, value := range changes {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			target[key] = value
+			continue
+		}
+
+		current, ok := target[key].(map[string]interface{})
+		if !ok {
+			current = map[string]interface{}{}
+		}
+		target[key] = merge(current, nested)
+	}
+	return target
+}
+
+//decode reads json keeping numbers as json.Number to not lose decimals of prices
+func decode(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(value)
+}